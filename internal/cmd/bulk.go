@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/bulk"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportSince       string
+	exportUntil       string
+	exportLimit       int64
+	exportOut         string
+	importBatchSize   int
+	importConcurrency int
+	importProgress    string
+	importFailures    string
+	bulkVerbose       bool
+	exportCmd         = &cobra.Command{
+		Use:   "export",
+		Short: "Exports statements as newline delimited JSON",
+		Long: `Exports statements as newline delimited JSON, oldest first, following more links until every page is written.
+The output is gzip compressed when its name ends with .gz.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			opt := bulk.ExportOptions{}
+
+			if len(exportSince) > 0 {
+				since, err := statement.ParseTimestamp(exportSince)
+
+				if err != nil {
+					return fmt.Errorf("invalid since: %w", err)
+				}
+
+				opt.Since = &since.Time
+			}
+
+			if len(exportUntil) > 0 {
+				until, err := statement.ParseTimestamp(exportUntil)
+
+				if err != nil {
+					return fmt.Errorf("invalid until: %w", err)
+				}
+
+				opt.Until = &until.Time
+			}
+
+			if exportLimit > 0 {
+				opt.Limit = &exportLimit
+			}
+
+			if bulkVerbose {
+				opt.Logger = log.New(os.Stderr, "", log.LstdFlags)
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			// Records the error of closing the output unless an error occurred first
+			closeOutput := func(fn func() error) {
+				if e := fn(); err == nil {
+					err = e
+				}
+			}
+
+			out := cmd.OutOrStdout()
+
+			if len(exportOut) > 0 && exportOut != "-" {
+				f, err := os.Create(exportOut)
+
+				if err != nil {
+					return err
+				}
+
+				defer closeOutput(f.Close)
+				out = f
+			}
+
+			// Statements written before a failure are flushed too
+			w := bufio.NewWriter(out)
+			defer closeOutput(w.Flush)
+
+			var gz *gzip.Writer
+
+			if strings.HasSuffix(exportOut, ".gz") {
+				gz = gzip.NewWriter(w)
+				defer closeOutput(gz.Close)
+			}
+
+			var stats *bulk.ExportStats
+
+			if gz != nil {
+				stats, err = bulk.Export(lrs, gz, &opt)
+			} else {
+				stats, err = bulk.Export(lrs, w, &opt)
+			}
+
+			if stats != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "statements: %d, voided: %d\n", stats.Statements, stats.Voided)
+			}
+
+			return err
+		},
+	}
+	importCmd = &cobra.Command{
+		Use:   "import [FILE]",
+		Short: "Validates and posts statements read as newline delimited JSON from a file, or stdin if omitted or -",
+		Long: `Validates and posts statements read as newline delimited JSON, gzip compressed or not, in batches.
+Invalid statements and statements rejected by the LRS are written to the failures file with the reason.
+With --progress, an interrupted import resumes after the batches already processed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var in io.Reader = cmd.InOrStdin()
+
+			if len(args) == 1 && args[0] != "-" {
+				f, err := os.Open(args[0])
+
+				if err != nil {
+					return err
+				}
+
+				defer f.Close()
+				in = f
+			}
+
+			in, err := decompress(in)
+
+			if err != nil {
+				return err
+			}
+
+			opt := bulk.ImportOptions{
+				BatchSize:    importBatchSize,
+				Concurrency:  importConcurrency,
+				ProgressFile: importProgress,
+				Failures:     cmd.ErrOrStderr(),
+			}
+
+			if len(importFailures) > 0 {
+				f, err := os.OpenFile(importFailures, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+
+				if err != nil {
+					return err
+				}
+
+				defer f.Close()
+				opt.Failures = f
+			}
+
+			if bulkVerbose {
+				opt.Logger = log.New(os.Stderr, "", log.LstdFlags)
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			stats, err := bulk.NewImporter(lrs, &opt).Run(in)
+
+			if stats != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "statements: %d, conflicts: %d, skipped: %d, failed: %d\n",
+					stats.Statements, stats.Conflicts, stats.Skipped, stats.Failed)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if stats.Failed > 0 {
+				return fmt.Errorf("%d statements couldn't be imported", stats.Failed)
+			}
+
+			return nil
+		},
+	}
+)
+
+// Returns a reader decompressing r when it holds gzip data
+func decompress(r io.Reader) (io.Reader, error) {
+	b := bufio.NewReader(r)
+	magic, err := b.Peek(2)
+
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(b)
+	}
+
+	return b, nil
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportSince, "since", "", "Only statements stored after this ISO 8601 timestamp")
+	exportCmd.Flags().StringVar(&exportUntil, "until", "", "Only statements stored at or before this ISO 8601 timestamp")
+	exportCmd.Flags().Int64Var(&exportLimit, "limit", 0, "Number of statements fetched per page")
+	exportCmd.Flags().StringVar(&exportOut, "out", "", "File the statements are written to, stdout if omitted or -")
+	exportCmd.Flags().BoolVar(&bulkVerbose, "verbose", false, "Log every page")
+
+	importCmd.Flags().IntVar(&importBatchSize, "batch-size", 100, "Number of statements posted per request")
+	importCmd.Flags().IntVar(&importConcurrency, "concurrency", 4, "Number of requests sent at the same time")
+	importCmd.Flags().StringVar(&importProgress, "progress", "", "File used to persist and resume import progress")
+	importCmd.Flags().StringVar(&importFailures, "failures", "", "File the rejected statements are appended to, stderr if omitted")
+	importCmd.Flags().BoolVar(&bulkVerbose, "verbose", false, "Log every batch")
+}
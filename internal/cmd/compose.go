@@ -0,0 +1,421 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/internal/output"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// Verbs of the ADL vocabulary suggested by the composer, voided is left out as it needs a StatementRef object
+var adlVerbs = []string{
+	"answered", "asked", "attempted", "attended", "commented", "completed", "exited", "experienced", "failed",
+	"imported", "initialized", "interacted", "launched", "mastered", "passed", "preferred", "progressed",
+	"registered", "responded", "resumed", "scored", "shared", "suspended", "terminated",
+}
+
+// Activity types of the ADL vocabulary suggested by the composer
+var adlActivityTypes = []string{
+	"assessment", "attempt", "course", "file", "interaction", "lesson", "link", "media", "meeting", "module",
+	"objective", "performance", "profile", "question", "simulation",
+}
+
+var (
+	composePost bool
+	composeCmd  = &cobra.Command{
+		Use:   "compose",
+		Short: "Interactively composes a statement and prints or posts it",
+		Long: `Prompts for the actor, verb, object and optionally the result of a statement, then prints it as JSON.
+Verbs and activity types can be given by their name in the ADL vocabulary, as in completed, or as IRIs.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p := prompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr()}
+
+			stmt, err := p.statement()
+
+			if err != nil {
+				return err
+			}
+
+			if err := stmt.Validate(); err != nil {
+				return fmt.Errorf("invalid statement: %w", err)
+			}
+
+			if !composePost {
+				return output.Write(cmd.OutOrStdout(), output.JSON, stmt, nil)
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			ids, resp, err := lrs.SaveStatement(*stmt)
+
+			if err != nil {
+				return err
+			}
+
+			if resp.Status >= 300 {
+				return resp.Err()
+			}
+
+			for _, id := range ids {
+				fmt.Fprintln(cmd.OutOrStdout(), id)
+			}
+
+			return nil
+		},
+	}
+)
+
+// Asks questions on out and reads the answers from in
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// Asks a question and returns the trimmed answer, or def when it is empty
+func (p *prompter) ask(question string, def string) (string, error) {
+	if len(def) > 0 {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	line, err := p.in.ReadString('\n')
+
+	if err != nil && (!errors.Is(err, io.EOF) || len(line) == 0) {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	line = strings.TrimSpace(line)
+
+	if len(line) == 0 {
+		return def, nil
+	}
+
+	return line, nil
+}
+
+// Asks a question until the answer passes check, which returns the value of the answer
+func askUntil[T any](p *prompter, question string, def string, check func(answer string) (T, error)) (T, error) {
+	for {
+		answer, err := p.ask(question, def)
+
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+
+		value, err := check(answer)
+
+		if err == nil {
+			return value, nil
+		}
+
+		fmt.Fprintln(p.out, err)
+	}
+}
+
+// Composes a statement from the answers
+func (p *prompter) statement() (*statement.Statement, error) {
+	actor, err := p.actor()
+
+	if err != nil {
+		return nil, err
+	}
+
+	verb, err := p.verb()
+
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := p.activity()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := statement.NewStatement(actor, *verb, object)
+	stmt.Timestamp = statement.NewTimestamp(time.Now().UTC())
+
+	add, err := askUntil(p, "Add a result? (y/n)", "n", parseBool)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if *add {
+		if stmt.Result, err = p.result(); err != nil {
+			return nil, err
+		}
+	}
+
+	return stmt, nil
+}
+
+// Asks for an agent identified by a mailbox or an account
+func (p *prompter) actor() (*statement.Agent, error) {
+	name, err := p.ask("Actor name", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := p.ask("Actor email, leave empty to use an account", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	var agent *statement.Agent
+
+	if len(email) > 0 {
+		agent = statement.NewAnonymousAgentWithMbox(email)
+	} else {
+		homePage, err := askUntil(p, "Account home page", "", requireIRI)
+
+		if err != nil {
+			return nil, err
+		}
+
+		accountName, err := askUntil(p, "Account name", "", requireValue)
+
+		if err != nil {
+			return nil, err
+		}
+
+		agent = statement.NewAnonymousAgentWithAccount(statement.NewAccount(homePage, accountName))
+	}
+
+	if len(name) > 0 {
+		agent.Name = &name
+	}
+
+	return agent, nil
+}
+
+// Asks for a verb, suggesting the ADL vocabulary
+func (p *prompter) verb() (*statement.Verb, error) {
+	fmt.Fprintf(p.out, "ADL verbs: %s\n", strings.Join(adlVerbs, ", "))
+
+	id, err := askUntil(p, "Verb name or IRI", "", func(answer string) (string, error) {
+		return vocabulary(answer, "http://adlnet.gov/expapi/verbs/", adlVerbs)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	display, err := p.ask("Verb display (en-US)", id[strings.LastIndexAny(id, "/#")+1:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return statement.NewVerb(id, statement.LanguageMap{"en-US": display}), nil
+}
+
+// Asks for an activity along with its name and type
+func (p *prompter) activity() (*statement.Activity, error) {
+	id, err := askUntil(p, "Activity IRI", "", requireIRI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := p.ask("Activity name (en-US), optional", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(p.out, "ADL activity types: %s\n", strings.Join(adlActivityTypes, ", "))
+
+	activityType, err := askUntil(p, "Activity type name or IRI, optional", "", func(answer string) (string, error) {
+		if len(answer) == 0 {
+			return "", nil
+		}
+
+		return vocabulary(answer, "http://adlnet.gov/expapi/activities/", adlActivityTypes)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(name) == 0 && len(activityType) == 0 {
+		return statement.NewActivity(id), nil
+	}
+
+	definition := &statement.ActivityDefinition{}
+
+	if len(name) > 0 {
+		definition.Name = &statement.LanguageMap{"en-US": name}
+	}
+
+	if len(activityType) > 0 {
+		definition.Type = &activityType
+	}
+
+	return statement.NewActivityWithDefiniton(id, definition), nil
+}
+
+// Asks for the properties of a result, each of them optional
+func (p *prompter) result() (*statement.Result, error) {
+	result := &statement.Result{}
+
+	raw, err := askUntil(p, "Raw score, optional", "", parseNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if raw != nil {
+		min, err := askUntil(p, "Minimum score, optional", "", parseNumber)
+
+		if err != nil {
+			return nil, err
+		}
+
+		max, err := askUntil(p, "Maximum score, optional", "", parseNumber)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Score = &statement.Score{Raw: raw, Min: min, Max: max}
+
+		if min != nil && max != nil {
+			if result.Score, err = statement.NewScore(*raw, *min, *max); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if result.Success, err = askUntil(p, "Success (y/n), optional", "", parseBool); err != nil {
+		return nil, err
+	}
+
+	if result.Completion, err = askUntil(p, "Completion (y/n), optional", "", parseBool); err != nil {
+		return nil, err
+	}
+
+	result.Duration, err = askUntil(p, "Duration as ISO 8601, as in PT1M30S, optional", "", func(answer string) (*statement.Duration, error) {
+		if len(answer) == 0 {
+			return nil, nil
+		}
+
+		d, err := statement.ParseDuration(answer)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &d, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.ask("Response, optional", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response) > 0 {
+		result.Response = &response
+	}
+
+	return result, nil
+}
+
+// Resolves an answer to an IRI, names of the vocabulary and unique prefixes of them are appended to base
+func vocabulary(answer string, base string, names []string) (string, error) {
+	if statement.IsIRI(answer) {
+		return answer, nil
+	}
+
+	var matches []string
+
+	for _, name := range names {
+		if name == answer {
+			return base + name, nil
+		}
+
+		if strings.HasPrefix(name, answer) {
+			matches = append(matches, name)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%q is neither an IRI nor in the vocabulary", answer)
+	case 1:
+		return base + matches[0], nil
+	default:
+		return "", fmt.Errorf("did you mean %s?", strings.Join(matches, ", "))
+	}
+}
+
+func requireIRI(answer string) (string, error) {
+	if !statement.IsIRI(answer) {
+		return "", fmt.Errorf("%q is not an IRI", answer)
+	}
+
+	return answer, nil
+}
+
+func requireValue(answer string) (string, error) {
+	if len(answer) == 0 {
+		return "", errors.New("a value is required")
+	}
+
+	return answer, nil
+}
+
+// Parses a yes or no answer, nil when empty
+func parseBool(answer string) (*bool, error) {
+	switch strings.ToLower(answer) {
+	case "":
+		return nil, nil
+	case "y", "yes":
+		return utils.Ptr(true), nil
+	case "n", "no":
+		return utils.Ptr(false), nil
+	default:
+		return nil, errors.New("answer y or n")
+	}
+}
+
+// Parses a number, nil when empty
+func parseNumber(answer string) (*float64, error) {
+	if len(answer) == 0 {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(answer, 64)
+
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a number", answer)
+	}
+
+	return &value, nil
+}
+
+func init() {
+	composeCmd.Flags().BoolVar(&composePost, "post", false, "Posts the statement instead of printing it")
+}
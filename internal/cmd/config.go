@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/burakkaraceylan/xapi-go/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	passwordStdin bool
+	makeDefault   bool
+	configCmd     = &cobra.Command{
+		Use:   "config",
+		Short: "Manages the LRS profiles of the configuration file",
+		Long: `Manages named LRS profiles so the endpoint, version and credentials don't have to be given on every invocation.
+Credentials are kept in credentials.yaml next to the configuration file, which only its owner may access.
+Settings are taken from the profile, then the XAPI_GO_* environment variables, then the flags.`,
+	}
+	addProfileCmd = &cobra.Command{
+		Use:   "add <name>",
+		Short: "Adds or replaces a profile using the --endpoint, --version and credential flags",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(endpoint) == 0 || len(version) == 0 {
+				return errors.New("you have to provide --endpoint and --version")
+			}
+
+			credentials := config.Credentials{Username: username, Password: password, Auth: auth}
+
+			if passwordStdin {
+				if len(username) == 0 || len(password) > 0 {
+					return errors.New("--password-stdin requires --username and can't be used with --password")
+				}
+
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+
+				if err != nil && len(line) == 0 {
+					return fmt.Errorf("failed to read password: %w", err)
+				}
+
+				credentials.Password = strings.TrimRight(line, "\r\n")
+			}
+
+			if len(credentials.Username) > 0 && len(credentials.Password) == 0 {
+				return errors.New("you have to provide both username and password")
+			}
+
+			path, err := configPath()
+
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.Load(path)
+
+			if err != nil {
+				return err
+			}
+
+			stored, err := config.LoadCredentials(config.CredentialsPath(path))
+
+			if err != nil {
+				return err
+			}
+
+			if cfg.Profiles == nil {
+				cfg.Profiles = map[string]*config.Profile{}
+			}
+
+			cfg.Profiles[args[0]] = &config.Profile{Endpoint: endpoint, Version: version}
+
+			if makeDefault || len(cfg.Profiles) == 1 {
+				cfg.Default = args[0]
+			}
+
+			if credentials.IsZero() {
+				delete(stored, args[0])
+			} else {
+				stored[args[0]] = credentials
+			}
+
+			if err := config.SaveCredentials(config.CredentialsPath(path), stored); err != nil {
+				return err
+			}
+
+			return cfg.Save(path)
+		},
+	}
+	listProfilesCmd = &cobra.Command{
+		Use:   "list",
+		Short: "Lists the profiles, the default one is marked with *",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := configPath()
+
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.Load(path)
+
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "\tNAME\tENDPOINT\tVERSION")
+
+			for _, name := range cfg.Names() {
+				mark := ""
+
+				if name == cfg.Default {
+					mark = "*"
+				}
+
+				p := cfg.Profiles[name]
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", mark, name, p.Endpoint, p.Version)
+			}
+
+			return w.Flush()
+		},
+	}
+	removeProfileCmd = &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Removes a profile and its stored credentials",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := configPath()
+
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.Load(path)
+
+			if err != nil {
+				return err
+			}
+
+			if _, ok := cfg.Profiles[args[0]]; !ok {
+				return fmt.Errorf("unknown profile %q", args[0])
+			}
+
+			delete(cfg.Profiles, args[0])
+
+			if cfg.Default == args[0] {
+				cfg.Default = ""
+			}
+
+			stored, err := config.LoadCredentials(config.CredentialsPath(path))
+
+			if err != nil {
+				return err
+			}
+
+			if _, ok := stored[args[0]]; ok {
+				delete(stored, args[0])
+
+				if err := config.SaveCredentials(config.CredentialsPath(path), stored); err != nil {
+					return err
+				}
+			}
+
+			return cfg.Save(path)
+		},
+	}
+)
+
+func init() {
+	addProfileCmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "Read the password from stdin instead of --password")
+	addProfileCmd.Flags().BoolVar(&makeDefault, "default", false, "Make the profile the default one")
+
+	configCmd.AddCommand(addProfileCmd)
+	configCmd.AddCommand(listProfilesCmd)
+	configCmd.AddCommand(removeProfileCmd)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"text/tabwriter"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/conformance"
+	"github.com/burakkaraceylan/xapi-go/pkg/lrs"
+	"github.com/spf13/cobra"
+)
+
+var (
+	conformanceOnly  []string
+	conformanceList  bool
+	conformanceLocal bool
+	conformanceCmd   = &cobra.Command{
+		Use:   "conformance",
+		Short: "Checks whether the LRS behaves as the xAPI specification requires",
+		Long: `Runs a battery of checks against the LRS and prints whether it passed each requirement.
+Checks store statements and documents about activities and agents unique to the run, so point it at a test
+instance. With --local, the checks run against an in-memory LRS instead, which shows what passing looks like.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+			if conformanceList {
+				for _, r := range conformance.Requirements() {
+					fmt.Fprintf(w, "%s\t%s\n", r.ID, r.Description)
+				}
+
+				return w.Flush()
+			}
+
+			var remote *client.RemoteLRS
+
+			if conformanceLocal {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+				if err != nil {
+					return fmt.Errorf("failed to start local LRS: %w", err)
+				}
+
+				defer listener.Close()
+
+				go http.Serve(listener, lrs.NewLRS())
+
+				if remote, err = client.NewRemoteLRS("http://"+listener.Addr().String()+"/", lrs.Version, "conformance", "conformance"); err != nil {
+					return err
+				}
+			} else {
+				var err error
+
+				if remote, err = connect(); err != nil {
+					return err
+				}
+			}
+
+			results, err := conformance.Run(remote, &conformance.Options{Requirements: conformanceOnly})
+
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+
+			for _, r := range results {
+				status := "PASS"
+
+				if !r.Passed {
+					status = "FAIL"
+					failed++
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\n", status, r.Requirement, r.Description)
+
+				if !r.Passed {
+					fmt.Fprintf(w, "\t\t%s\n", r.Error)
+				}
+			}
+
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d requirements failed", failed, len(results))
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "all %d requirements passed\n", len(results))
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	conformanceCmd.Flags().StringSliceVar(&conformanceOnly, "only", nil, "Ids of the requirements to check, every requirement if omitted")
+	conformanceCmd.Flags().BoolVar(&conformanceList, "list", false, "List the requirements without checking them")
+	conformanceCmd.Flags().BoolVar(&conformanceLocal, "local", false, "Check an in-memory LRS instead of the configured one")
+}
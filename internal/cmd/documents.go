@@ -0,0 +1,479 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+	"unicode/utf8"
+
+	"github.com/burakkaraceylan/xapi-go/internal/output"
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/documents"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/spf13/cobra"
+)
+
+// Flags shared by the document commands
+type documentFlags struct {
+	agent        agentFlags
+	activity     string
+	registration string
+	since        string
+	etag         string
+	contentType  string
+	file         string
+	all          bool
+}
+
+// Describes a document resource of the LRS
+type documentResource struct {
+	name         string
+	idName       string
+	hasActivity  bool
+	hasAgent     bool
+	registration bool
+	list         func(lrs *client.RemoteLRS, f *documentFlags, since *time.Time) ([]string, *client.Response, error)
+	get          func(lrs *client.RemoteLRS, f *documentFlags, id string) (*documents.Document, *client.Response, error)
+	save         func(lrs *client.RemoteLRS, f *documentFlags, doc documents.Document) (*client.Response, error)
+	delete       func(lrs *client.RemoteLRS, f *documentFlags, doc documents.Document) (*client.Response, error)
+}
+
+// Checks the flags identifying the documents of the resource are set
+func (f *documentFlags) check(res *documentResource) error {
+	if res.hasAgent && f.agent.agent() == nil {
+		return errors.New("you have to identify the agent with one of the --agent flags")
+	}
+
+	return nil
+}
+
+// Returns the registration flag, or nil if it wasn't set
+func (f *documentFlags) registrationParam() *string {
+	if len(f.registration) == 0 {
+		return nil
+	}
+
+	return &f.registration
+}
+
+// Returns the content type of a document: the explicit one, the one of the file extension,
+// JSON when the content is valid JSON, or the sniffed one
+func detectContentType(explicit string, file string, content []byte) string {
+	if len(explicit) > 0 {
+		return explicit
+	}
+
+	if len(file) > 0 {
+		if ct := mime.TypeByExtension(filepath.Ext(file)); len(ct) > 0 {
+			return ct
+		}
+	}
+
+	if json.Valid(content) {
+		return "application/json"
+	}
+
+	return http.DetectContentType(content)
+}
+
+// Reads a document body from a file, or stdin if omitted or -
+func readDocument(cmd *cobra.Command, args []string) ([]byte, string, error) {
+	if len(args) == 0 || args[0] == "-" {
+		b, err := io.ReadAll(cmd.InOrStdin())
+
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read document: %w", err)
+		}
+
+		return b, "", nil
+	}
+
+	b, err := os.ReadFile(args[0])
+
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read document: %w", err)
+	}
+
+	return b, args[0], nil
+}
+
+// Prints the metadata of a document to stderr, keeping stdout for the body
+func printDocumentInfo(cmd *cobra.Command, doc *documents.Document) {
+	w := cmd.ErrOrStderr()
+
+	if len(doc.Etag) > 0 {
+		fmt.Fprintf(w, "ETag: %s\n", doc.Etag)
+	}
+
+	if !doc.Timestamp.IsZero() {
+		fmt.Fprintf(w, "Last-Modified: %s\n", doc.Timestamp.UTC().Format(http.TimeFormat))
+	}
+
+	if len(doc.ContentType) > 0 {
+		fmt.Fprintf(w, "Content-Type: %s\n", doc.ContentType)
+	}
+}
+
+// A document as written by get in an output format
+type documentRecord struct {
+	ID           string `json:"id"`
+	ContentType  string `json:"contentType,omitempty"`
+	Etag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	// JSON content is embedded as is, any other as a string, or base64 encoded when it isn't valid UTF-8
+	Content any `json:"content,omitempty"`
+}
+
+// Returns the record of a document
+func newDocumentRecord(id string, doc *documents.Document) documentRecord {
+	record := documentRecord{ID: id, ContentType: doc.ContentType, Etag: doc.Etag, Content: doc.Content}
+
+	if !doc.Timestamp.IsZero() {
+		record.LastModified = statement.NewTimestamp(doc.Timestamp.UTC()).String()
+	}
+
+	switch {
+	case json.Valid(doc.Content):
+		record.Content = json.RawMessage(doc.Content)
+	case utf8.Valid(doc.Content):
+		record.Content = string(doc.Content)
+	}
+
+	return record
+}
+
+// Registers the flags identifying the documents of a resource
+func (res *documentResource) registerFlags(cmd *cobra.Command, f *documentFlags) {
+	if res.hasActivity {
+		cmd.Flags().StringVar(&f.activity, "activity", "", "Activity IRI")
+
+		if err := cmd.MarkFlagRequired("activity"); err != nil {
+			panic(err)
+		}
+	}
+
+	if res.hasAgent {
+		f.agent.register(cmd, "agent", "Agent's")
+	}
+
+	if res.registration {
+		cmd.Flags().StringVar(&f.registration, "registration", "", "Registration the documents belong to")
+	}
+}
+
+// Creates the list, get, put and delete commands of a document resource
+func newDocumentCmd(use string, short string, res *documentResource) *cobra.Command {
+	var f documentFlags
+	var listOutput, getOutput outputFlags
+
+	parent := &cobra.Command{
+		Use:   use,
+		Short: short,
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the ids of the " + res.name + " documents",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := listOutput.parse(); err != nil {
+				return err
+			}
+
+			var since *time.Time
+
+			if cmd.Flags().Changed("since") {
+				ts, err := statement.ParseTimestamp(f.since)
+
+				if err != nil {
+					return fmt.Errorf("invalid since: %w", err)
+				}
+
+				since = &ts.Time
+			}
+
+			if err := f.check(res); err != nil {
+				return err
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			ids, resp, err := res.list(lrs, &f, since)
+
+			if err != nil {
+				return err
+			}
+
+			if ids == nil {
+				return resp.Err()
+			}
+
+			records := make([]documentRecord, len(ids))
+
+			for i, id := range ids {
+				records[i] = documentRecord{ID: id}
+			}
+
+			return listOutput.write(cmd, records)
+		},
+	}
+
+	get := &cobra.Command{
+		Use:   "get <" + res.idName + ">",
+		Short: "Prints a " + res.name + " document, its ETag and last modification time go to stderr",
+		Long: `Prints a ` + res.name + ` document, its ETag and last modification time go to stderr.
+With --output or --fields, the document is printed as a record holding its id, content type, ETag,
+last modification time and content instead.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			record := cmd.Flags().Changed("output") || cmd.Flags().Changed("fields")
+
+			if record {
+				if err := getOutput.parse(); err != nil {
+					return err
+				}
+			}
+
+			if err := f.check(res); err != nil {
+				return err
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			doc, resp, err := res.get(lrs, &f, args[0])
+
+			if err != nil {
+				return err
+			}
+
+			if doc == nil {
+				return resp.Err()
+			}
+
+			if record {
+				return getOutput.write(cmd, newDocumentRecord(args[0], doc))
+			}
+
+			printDocumentInfo(cmd, doc)
+
+			if len(f.file) > 0 {
+				return os.WriteFile(f.file, doc.Content, 0644)
+			}
+
+			_, err = cmd.OutOrStdout().Write(doc.Content)
+
+			return err
+		},
+	}
+
+	put := &cobra.Command{
+		Use:   "put <" + res.idName + "> [FILE]",
+		Short: "Stores a " + res.name + " document read from a file, or stdin if omitted or -",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := f.check(res); err != nil {
+				return err
+			}
+
+			content, file, err := readDocument(cmd, args[1:])
+
+			if err != nil {
+				return err
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			resp, err := res.save(lrs, &f, documents.Document{
+				ID:          args[0],
+				ContentType: detectContentType(f.contentType, file, content),
+				Content:     content,
+				Etag:        f.etag,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			if resp.Status >= 300 {
+				return resp.Err()
+			}
+
+			if etag := resp.Response.Header.Get("ETag"); len(etag) > 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "ETag: %s\n", etag)
+			}
+
+			return nil
+		},
+	}
+
+	deleteArgs := cobra.ExactArgs(1)
+	deleteUse := "delete <" + res.idName + ">"
+
+	if res.registration {
+		deleteArgs = cobra.MaximumNArgs(1)
+		deleteUse = "delete [" + res.idName + "]"
+	}
+
+	del := &cobra.Command{
+		Use:   deleteUse,
+		Short: "Deletes a " + res.name + " document",
+		Args:  deleteArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !f.all {
+				return errors.New("you have to give a " + res.idName + " or --all to delete every document")
+			}
+
+			if len(args) == 1 && f.all {
+				return errors.New("--all can't be used with a " + res.idName)
+			}
+
+			if err := f.check(res); err != nil {
+				return err
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			doc := documents.Document{Etag: f.etag}
+
+			if len(args) == 1 {
+				doc.ID = args[0]
+			}
+
+			resp, err := res.delete(lrs, &f, doc)
+
+			if err != nil {
+				return err
+			}
+
+			if resp.Status >= 300 {
+				return resp.Err()
+			}
+
+			return nil
+		},
+	}
+
+	for _, c := range []*cobra.Command{list, get, put, del} {
+		res.registerFlags(c, &f)
+	}
+
+	list.Flags().StringVar(&f.since, "since", "", "Only documents stored after this ISO 8601 timestamp")
+	get.Flags().StringVar(&f.file, "file", "", "Write the document to this file instead of stdout")
+	listOutput.registerFormat(list, output.Table, []string{"id"})
+	getOutput.register(get, []string{"id", "contentType", "etag", "lastModified"})
+	get.MarkFlagsMutuallyExclusive("file", "output")
+	get.MarkFlagsMutuallyExclusive("file", "fields")
+	put.Flags().StringVar(&f.contentType, "content-type", "", "Content type of the document, detected from the file or content when omitted")
+	put.Flags().StringVar(&f.etag, "etag", "", "Only store if the document still has this ETag")
+	del.Flags().StringVar(&f.etag, "etag", "", "Only delete if the document still has this ETag")
+
+	if res.registration {
+		del.Flags().BoolVar(&f.all, "all", false, "Delete every document of the agent and activity")
+	}
+
+	parent.AddCommand(list, get, put, del)
+
+	return parent
+}
+
+var (
+	stateCmd = newDocumentCmd("state", "Lists, reads, writes and deletes state documents", &documentResource{
+		name:         "state",
+		idName:       "stateId",
+		hasActivity:  true,
+		hasAgent:     true,
+		registration: true,
+		list: func(lrs *client.RemoteLRS, f *documentFlags, since *time.Time) ([]string, *client.Response, error) {
+			return lrs.GetStateIds(statement.Activity{ID: f.activity}, *f.agent.agent(), &client.GetStateIdsOptionalParams{Registration: f.registrationParam(), Since: since})
+		},
+		get: func(lrs *client.RemoteLRS, f *documentFlags, id string) (*documents.Document, *client.Response, error) {
+			doc, resp, err := lrs.GetState(statement.Activity{ID: f.activity}, *f.agent.agent(), id, &client.GetStateOptionalParams{Registration: f.registrationParam()})
+
+			if doc == nil {
+				return nil, resp, err
+			}
+
+			return &doc.Document, resp, err
+		},
+		save: func(lrs *client.RemoteLRS, f *documentFlags, doc documents.Document) (*client.Response, error) {
+			_, resp, err := lrs.SaveState(&documents.StateDocument{Document: doc, Activity: statement.Activity{ID: f.activity}, Agent: *f.agent.agent(), Registration: f.registrationParam()})
+
+			return resp, err
+		},
+		delete: func(lrs *client.RemoteLRS, f *documentFlags, doc documents.Document) (*client.Response, error) {
+			return lrs.DeleteState(&documents.StateDocument{Document: doc, Activity: statement.Activity{ID: f.activity}, Agent: *f.agent.agent(), Registration: f.registrationParam()})
+		},
+	})
+	activityProfileCmd = newDocumentCmd("activity-profile", "Lists, reads, writes and deletes activity profile documents", &documentResource{
+		name:        "activity profile",
+		idName:      "profileId",
+		hasActivity: true,
+		list: func(lrs *client.RemoteLRS, f *documentFlags, since *time.Time) ([]string, *client.Response, error) {
+			return lrs.GetActivityProfileIds(statement.Activity{ID: f.activity}, &client.GetActivityProfileIdsOptionalParams{Since: since})
+		},
+		get: func(lrs *client.RemoteLRS, f *documentFlags, id string) (*documents.Document, *client.Response, error) {
+			doc, resp, err := lrs.GetActivityProfile(statement.Activity{ID: f.activity}, id)
+
+			if doc == nil {
+				return nil, resp, err
+			}
+
+			return &doc.Document, resp, err
+		},
+		save: func(lrs *client.RemoteLRS, f *documentFlags, doc documents.Document) (*client.Response, error) {
+			_, resp, err := lrs.SaveActivityProfile(&documents.ActivityDocument{Document: doc, Activity: statement.Activity{ID: f.activity}})
+
+			return resp, err
+		},
+		delete: func(lrs *client.RemoteLRS, f *documentFlags, doc documents.Document) (*client.Response, error) {
+			return lrs.DeleteActivityProfile(&documents.ActivityDocument{Document: doc, Activity: statement.Activity{ID: f.activity}})
+		},
+	})
+	agentProfileCmd = newDocumentCmd("agent-profile", "Lists, reads, writes and deletes agent profile documents", &documentResource{
+		name:     "agent profile",
+		idName:   "profileId",
+		hasAgent: true,
+		list: func(lrs *client.RemoteLRS, f *documentFlags, since *time.Time) ([]string, *client.Response, error) {
+			return lrs.GetAgentProfileIds(*f.agent.agent(), &client.GetAgentProfileIdsoptionalParams{Since: since})
+		},
+		get: func(lrs *client.RemoteLRS, f *documentFlags, id string) (*documents.Document, *client.Response, error) {
+			doc, resp, err := lrs.GetAgentProfile(*f.agent.agent(), id)
+
+			if doc == nil {
+				return nil, resp, err
+			}
+
+			return &doc.Document, resp, err
+		},
+		save: func(lrs *client.RemoteLRS, f *documentFlags, doc documents.Document) (*client.Response, error) {
+			_, resp, err := lrs.SaveAgentProfile(&documents.AgentDocument{Document: doc, Agent: *f.agent.agent()})
+
+			return resp, err
+		},
+		delete: func(lrs *client.RemoteLRS, f *documentFlags, doc documents.Document) (*client.Response, error) {
+			return lrs.DeleteAgentProfile(&documents.AgentDocument{Document: doc, Agent: *f.agent.agent()})
+		},
+	})
+)
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/lint"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [FILE...]",
+	Short: "Checks statements read from files, or stdin if omitted or -, against the xAPI specification",
+	Long: `Checks a statement, or an array of statements, against the xAPI specification without sending it to an LRS.
+Every problem is printed with the path of the offending property, and the command fails when any is found.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			args = []string{"-"}
+		}
+
+		problems := 0
+
+		for _, name := range args {
+			var data []byte
+			var err error
+
+			if name == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(name)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			prefix := ""
+
+			if name != "-" {
+				prefix = name + ": "
+			}
+
+			errs, err := lint.Lint(data)
+
+			if err != nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s%s\n", prefix, err)
+				problems++
+				continue
+			}
+
+			for _, e := range errs {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s%s\n", prefix, e)
+			}
+
+			problems += len(errs)
+		}
+
+		if problems == 1 {
+			return errors.New("1 problem found")
+		}
+
+		if problems > 1 {
+			return fmt.Errorf("%d problems found", problems)
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"github.com/burakkaraceylan/xapi-go/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// Flags selecting how results are written
+type outputFlags struct {
+	format   string
+	fields   output.FieldList
+	defaults []string
+	parsed   output.Format
+	selected []output.Field
+}
+
+// Registers the output flags, defaults are the fields written in the table and CSV formats when none are selected
+func (f *outputFlags) register(cmd *cobra.Command, defaults []string) {
+	f.registerFormat(cmd, output.JSON, defaults)
+}
+
+// Registers the output flags with another format than JSON by default
+func (f *outputFlags) registerFormat(cmd *cobra.Command, format output.Format, defaults []string) {
+	f.defaults = defaults
+	cmd.Flags().StringVar(&f.format, "output", string(format), "Output format: json, ndjson, table or csv")
+	cmd.Flags().Var(&f.fields, "fields", `Fields to output, as in actor.name,result.extensions["http://example.com/ext"]`)
+}
+
+// Parses the flags, so invalid ones are reported before querying the LRS
+func (f *outputFlags) parse() error {
+	format, err := output.ParseFormat(f.format)
+
+	if err != nil {
+		return err
+	}
+
+	fields := f.fields
+
+	if len(fields) == 0 && (format == output.Table || format == output.CSV) {
+		fields = f.defaults
+	}
+
+	selected, err := output.ParseFields(fields)
+
+	if err != nil {
+		return err
+	}
+
+	f.parsed = format
+	f.selected = selected
+
+	return nil
+}
+
+// Writes values to the command's output
+func (f *outputFlags) write(cmd *cobra.Command, values any) error {
+	return output.Write(cmd.OutOrStdout(), f.parsed, values, f.selected)
+}
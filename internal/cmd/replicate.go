@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/internal/config"
+	"github.com/burakkaraceylan/xapi-go/pkg/replicate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	targetEndpoint string
+	targetVersion  string
+	targetUsername string
+	targetPassword string
+	targetAuth     string
+	targetProfile  string
+	checkpointFile string
+	replicateLimit int64
+	replicateUntil string
+	copyDocuments  bool
+	verbose        bool
+	replicateCmd   = &cobra.Command{
+		Use:   "replicate",
+		Short: "Copies statements and documents from the LRS to a target LRS",
+		Long: `Copies every statement stored on the LRS to the target LRS, preserving statement ids.
+Progress is recorded in a checkpoint file so an interrupted replication resumes where it stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			t := &config.Profile{}
+
+			if len(targetProfile) > 0 {
+				t, err = loadProfile(targetProfile)
+
+				if err != nil {
+					return fmt.Errorf("target: %w", err)
+				}
+			}
+
+			t.Merge(config.Profile{
+				Endpoint:    targetEndpoint,
+				Version:     targetVersion,
+				Credentials: config.Credentials{Username: targetUsername, Password: targetPassword, Auth: targetAuth},
+			})
+
+			if len(t.Version) == 0 {
+				t.Version = source.Version
+			}
+
+			target, err := newLRS(t.Endpoint, t.Version, t.Username, t.Password, t.Auth)
+
+			if err != nil {
+				return fmt.Errorf("target: %w", err)
+			}
+
+			opt := replicate.Options{
+				CheckpointFile: checkpointFile,
+				Documents:      copyDocuments,
+			}
+
+			if replicateLimit > 0 {
+				opt.Limit = &replicateLimit
+			}
+
+			if len(replicateUntil) > 0 {
+				until, err := time.Parse(time.RFC3339Nano, replicateUntil)
+
+				if err != nil {
+					return fmt.Errorf("invalid until: %w", err)
+				}
+
+				opt.Until = &until
+			}
+
+			if verbose {
+				opt.Logger = log.New(os.Stderr, "", log.LstdFlags)
+			}
+
+			stats, err := replicate.NewReplicator(source, target, &opt).Run()
+
+			if stats != nil {
+				fmt.Printf("statements: %d, voided: %d, conflicts: %d, skipped: %d, documents: %d\n",
+					stats.Statements, stats.Voided, stats.Conflicts, stats.Skipped, stats.Documents)
+			}
+
+			return err
+		},
+	}
+)
+
+func init() {
+	replicateCmd.Flags().StringVar(&targetProfile, "target-profile", "", "Configuration profile of the target LRS")
+	replicateCmd.Flags().StringVar(&targetEndpoint, "target-endpoint", "", "URL of the target API endpoint")
+	replicateCmd.Flags().StringVar(&targetVersion, "target-version", "", "Target API version, defaults to the source version")
+	replicateCmd.Flags().StringVar(&targetUsername, "target-username", "", "Target API user's username")
+	replicateCmd.Flags().StringVar(&targetPassword, "target-password", "", "Target API user's password")
+	replicateCmd.MarkFlagsRequiredTogether("target-username", "target-password")
+	replicateCmd.Flags().StringVar(&targetAuth, "target-auth", "", "Target authentication header (Basic, Bearer etc...)")
+	replicateCmd.MarkFlagsMutuallyExclusive("target-username", "target-auth")
+	replicateCmd.Flags().StringVar(&checkpointFile, "checkpoint", "", "File used to persist and resume replication progress")
+	replicateCmd.Flags().Int64Var(&replicateLimit, "limit", 0, "Number of statements fetched per page")
+	replicateCmd.Flags().StringVar(&replicateUntil, "until", "", "Only replicate statements stored before this RFC 3339 timestamp")
+	replicateCmd.Flags().BoolVar(&copyDocuments, "documents", false, "Also copy state and profile documents")
+	replicateCmd.Flags().BoolVar(&verbose, "verbose", false, "Log every replicated statement")
+}
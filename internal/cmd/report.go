@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/burakkaraceylan/xapi-go/internal/output"
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/report"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarizes the statements matching a filter by agent and activity",
+	Long: `Fetches the statements matching the filters and summarizes them per agent and activity, or per activity.
+Summaries count statements, completions, passes and failures, and hold the first and last attempt, the best and
+average scaled score and the total duration of the results.`,
+}
+
+// Flags filtering the statements of a report
+type reportFilter struct {
+	agent        agentFlags
+	activity     string
+	related      bool
+	verb         string
+	registration string
+	since        string
+	until        string
+}
+
+// Registers the filter flags
+func (f *reportFilter) register(cmd *cobra.Command) {
+	f.agent.register(cmd, "agent", "Agent's")
+	cmd.Flags().StringVar(&f.activity, "activity", "", "Activity IRI to filter by")
+	cmd.Flags().BoolVar(&f.related, "related-activities", false, "Also match the activity in the context activities, summarizing the statements about its children")
+	cmd.Flags().StringVar(&f.verb, "verb", "", "Verb IRI to filter by")
+	cmd.Flags().StringVar(&f.registration, "registration", "", "Registration to filter by")
+	cmd.Flags().StringVar(&f.since, "since", "", "Only summarize statements stored after this ISO 8601 timestamp")
+	cmd.Flags().StringVar(&f.until, "until", "", "Only summarize statements stored at or before this ISO 8601 timestamp")
+}
+
+// Returns the query described by the flags
+func (f *reportFilter) query() (*client.StatementQueryParams, error) {
+	query := &client.StatementQueryParams{Agent: f.agent.agent()}
+
+	if len(f.activity) > 0 {
+		query.Activity = &statement.Activity{ID: f.activity}
+
+		if f.related {
+			query.RelatedActivities = utils.Ptr(true)
+		}
+	}
+
+	if len(f.verb) > 0 {
+		query.Verb = &statement.Verb{ID: f.verb}
+	}
+
+	if len(f.registration) > 0 {
+		query.Registeration = &f.registration
+	}
+
+	if len(f.since) > 0 {
+		since, err := statement.ParseTimestamp(f.since)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+
+		query.Since = &since.Time
+	}
+
+	if len(f.until) > 0 {
+		until, err := statement.ParseTimestamp(f.until)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid until: %w", err)
+		}
+
+		query.Until = &until.Time
+	}
+
+	return query, nil
+}
+
+// Creates a report command summarizing statements with the grouping, writing the default fields as a table
+func newReportCmd(use string, short string, grouping report.Grouping, defaults []string) *cobra.Command {
+	var filter reportFilter
+	var out outputFlags
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := out.parse(); err != nil {
+				return err
+			}
+
+			query, err := filter.query()
+
+			if err != nil {
+				return err
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			aggregator := report.NewAggregator(grouping)
+			count, err := aggregator.Query(lrs, query)
+
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "summarized %d statements\n", count)
+
+			return out.write(cmd, aggregator.Summaries())
+		},
+	}
+
+	filter.register(cmd)
+	out.registerFormat(cmd, output.Table, defaults)
+
+	return cmd
+}
+
+func init() {
+	reportCmd.AddCommand(newReportCmd("completions", "Lists whether each agent completed each activity", report.ByAgentActivity,
+		[]string{"agent_name", "agent", "activity", "completed", "first_completion", "statements", "last_attempt"}))
+	reportCmd.AddCommand(newReportCmd("scores", "Lists the scores of each agent on each activity", report.ByAgentActivity,
+		[]string{"agent_name", "agent", "activity", "scores", "best_score", "average_score", "pass_rate", "duration"}))
+	reportCmd.AddCommand(newReportCmd("activity-summary", "Summarizes the statements about each activity across agents", report.ByActivity,
+		[]string{"activity", "activity_name", "learners", "statements", "completions", "pass_rate", "best_score", "average_score", "duration", "first_attempt", "last_attempt"}))
+}
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/burakkaraceylan/xapi-go/internal/config"
+	"github.com/burakkaraceylan/xapi-go/internal/output"
 	"github.com/burakkaraceylan/xapi-go/pkg/client"
-	"github.com/burakkaraceylan/xapi-go/pkg/utils"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -15,6 +19,8 @@ var (
 	password string
 	auth     string
 	version  string
+	profile  string
+	cfgFile  string
 	rootCmd  = &cobra.Command{
 		Use:   "xapi-go",
 		Short: "xapi-go is a xApi client written in Go",
@@ -23,79 +29,133 @@ var (
 			// Do Stuff Here
 		},
 	}
-	getStatement = &cobra.Command{
+	getStatementOutput outputFlags
+	aboutOutput        outputFlags
+	getStatement       = &cobra.Command{
 		Use:  "getStatement [OPTIONS]",
 		Args: cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			var lrs *client.RemoteLRS
-			var err error
-
-			if len(username) > 0 {
-
-				if len(password) == 0 {
-					panic("You have to provide both username and password")
-				}
-
-				lrs, err = client.NewRemoteLRS(endpoint, version, username, password)
-
-			} else if len(auth) > 0 {
-				lrs, err = client.NewRemoteLRS(endpoint, version, auth)
-			} else {
-				panic("You have to provide either a username/password pair or an authorization header")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := getStatementOutput.parse(); err != nil {
+				return err
 			}
 
+			lrs, err := connect()
+
 			if err != nil {
-				panic(err)
+				return err
 			}
 
-			stmt, _, err := lrs.GetStatement(args[0])
+			stmt, resp, err := lrs.GetStatement(args[0])
 
 			if err != nil {
-				panic(err)
+				return err
 			}
 
-			str, _ := utils.ToJson(stmt, true)
-			fmt.Println(str)
+			if stmt == nil {
+				return resp.Err()
+			}
 
+			return getStatementOutput.write(cmd, stmt)
 		},
 	}
 	about = &cobra.Command{
 		Use: "about",
-		Run: func(cmd *cobra.Command, args []string) {
-			var lrs *client.RemoteLRS
-			var err error
-
-			if len(username) > 0 {
-
-				if len(password) == 0 {
-					panic("You have to provide both username and password")
-				}
-
-				lrs, err = client.NewRemoteLRS(endpoint, version, username, password)
-
-			} else if len(auth) > 0 {
-				lrs, err = client.NewRemoteLRS(endpoint, version, auth)
-			} else {
-				panic("You have to provide either a username/password pair or an authorization header")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := aboutOutput.parse(); err != nil {
+				return err
 			}
 
+			lrs, err := connect()
+
 			if err != nil {
-				panic(err)
+				return err
 			}
 
 			stmt, err := lrs.About()
 
 			if err != nil {
-				panic(err)
+				return err
 			}
 
-			str, _ := utils.ToJson(stmt, true)
-			fmt.Println(str)
-
+			return aboutOutput.write(cmd, stmt)
 		},
 	}
 )
 
+// connect creates a RemoteLRS from the selected profile, environment variables and global flags, in increasing priority
+func connect() (*client.RemoteLRS, error) {
+	name := profile
+
+	if len(name) == 0 {
+		name = os.Getenv(config.EnvProfile)
+	}
+
+	p, err := loadProfile(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.Merge(config.FromEnv())
+	p.Merge(config.Profile{
+		Endpoint:    endpoint,
+		Version:     version,
+		Credentials: config.Credentials{Username: username, Password: password, Auth: auth},
+	})
+
+	return newLRS(p.Endpoint, p.Version, p.Username, p.Password, p.Auth)
+}
+
+// Returns the path of the configuration file
+func configPath() (string, error) {
+	if len(cfgFile) > 0 {
+		return cfgFile, nil
+	}
+
+	return config.DefaultPath()
+}
+
+// Returns the named profile, or the default one when name is empty
+func loadProfile(name string) (*config.Profile, error) {
+	path, err := configPath()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.Resolve(name, config.CredentialsPath(path))
+}
+
+func newLRS(endpoint string, version string, username string, password string, auth string) (*client.RemoteLRS, error) {
+	if len(endpoint) == 0 {
+		return nil, errors.New("you have to provide an endpoint with --endpoint, " + config.EnvEndpoint + " or a profile")
+	}
+
+	if len(version) == 0 {
+		return nil, errors.New("you have to provide an API version with --version, " + config.EnvVersion + " or a profile")
+	}
+
+	if len(username) > 0 {
+		if len(password) == 0 {
+			return nil, errors.New("you have to provide both username and password")
+		}
+
+		return client.NewRemoteLRS(endpoint, version, username, password)
+	}
+
+	if len(auth) > 0 {
+		return client.NewRemoteLRS(endpoint, version, auth)
+	}
+
+	return nil, errors.New("you have to provide either a username/password pair or an authorization header")
+}
+
 // Execute the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
@@ -104,26 +164,82 @@ func Execute() {
 	}
 }
 
-// Initialize CLI args
-func init() {
-	rootCmd.PersistentFlags().StringVar(&endpoint, "endpoint", "", "URL of the API endpoint")
+// Run runs the command given by args, reading from in and writing to out and errOut. Flags set by a previous
+// run are reset to their defaults first.
+func Run(args []string, in io.Reader, out io.Writer, errOut io.Writer) error {
+	if err := resetFlags(rootCmd); err != nil {
+		return err
+	}
 
-	if err := rootCmd.MarkPersistentFlagRequired("endpoint"); err != nil {
-		panic(err)
+	rootCmd.SetArgs(args)
+	rootCmd.SetIn(in)
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(errOut)
+
+	return rootCmd.Execute()
+}
+
+// Resets the flags of a command and its subcommands to their defaults
+func resetFlags(cmd *cobra.Command) error {
+	var err error
+
+	reset := func(f *pflag.Flag) {
+		var e error
+
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			e = sv.Replace(nil)
+		} else {
+			e = f.Value.Set(f.DefValue)
+		}
+
+		if e != nil && err == nil {
+			err = fmt.Errorf("failed to reset --%s: %w", f.Name, e)
+		}
+
+		f.Changed = false
 	}
 
-	rootCmd.PersistentFlags().StringVar(&version, "version", "", "API version")
+	cmd.PersistentFlags().VisitAll(reset)
+	cmd.Flags().VisitAll(reset)
 
-	if err := rootCmd.MarkPersistentFlagRequired("version"); err != nil {
-		panic(err)
+	for _, sub := range cmd.Commands() {
+		if e := resetFlags(sub); e != nil && err == nil {
+			err = e
+		}
 	}
 
+	return err
+}
+
+// Initialize CLI args
+func init() {
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "Configuration file, defaults to $"+config.EnvConfig+" or xapi-go/config.yaml in the user's configuration directory")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "Configuration profile to use, defaults to $"+config.EnvProfile+" or the default profile")
+	rootCmd.PersistentFlags().StringVar(&endpoint, "endpoint", "", "URL of the API endpoint")
+	rootCmd.PersistentFlags().StringVar(&version, "version", "", "API version")
+
 	rootCmd.PersistentFlags().StringVar(&username, "username", "", "API user's username")
 	rootCmd.PersistentFlags().StringVar(&password, "password", "", "API user's password")
-	rootCmd.MarkFlagsRequiredTogether("username", "password")
 	rootCmd.PersistentFlags().StringVar(&auth, "auth", "", "Authentication header (Basic, Bearer etc...)")
 	rootCmd.MarkFlagsMutuallyExclusive("username", "auth")
 
+	getStatementOutput.register(getStatement, output.StatementFields)
+	aboutOutput.register(about, []string{"version"})
+
 	rootCmd.AddCommand(getStatement)
 	rootCmd.AddCommand(about)
+	rootCmd.AddCommand(replicateCmd)
+	rootCmd.AddCommand(statementsCmd)
+	rootCmd.AddCommand(stateCmd)
+	rootCmd.AddCommand(activityProfileCmd)
+	rootCmd.AddCommand(agentProfileCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(composeCmd)
+	rootCmd.AddCommand(tailCmd)
+	rootCmd.AddCommand(conformanceCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(reportCmd)
 }
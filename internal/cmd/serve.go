@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/lrs"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr string
+	serveDB   string
+	serveCmd  = &cobra.Command{
+		Use:   "serve",
+		Short: "Runs a local LRS to develop and test content against",
+		Long: `Runs an LRS serving the statement, document and about resources until interrupted, logging every request.
+Statements and documents are kept in memory, or in the --db file so they survive restarts. With --username and
+--password, requests must authenticate with Basic auth. Browsers may send requests from any origin.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(username) > 0) != (len(password) > 0) {
+				return errors.New("you have to provide both username and password")
+			}
+
+			logger := log.New(os.Stderr, "", log.LstdFlags)
+			opt := &lrs.Options{Username: username, Password: password, Logger: logger}
+
+			var l *lrs.LRS
+
+			if len(serveDB) > 0 {
+				var err error
+
+				if l, err = lrs.Open(serveDB, opt); err != nil {
+					return err
+				}
+			} else {
+				l = lrs.NewLRS(opt)
+			}
+
+			listener, err := net.Listen("tcp", serveAddr)
+
+			if err != nil {
+				return fmt.Errorf("failed to listen: %w", err)
+			}
+
+			server := &http.Server{Handler: lrs.LogRequests(lrs.CORS(l), logger)}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			go func() {
+				<-ctx.Done()
+
+				shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+
+				server.Shutdown(shutdown)
+			}()
+
+			logger.Printf("serving xAPI %s at http://%s/", lrs.Version, listener.Addr())
+
+			if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().StringVar(&serveDB, "db", "", "File statements and documents are persisted to, kept in memory only if omitted")
+}
@@ -0,0 +1,397 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/burakkaraceylan/xapi-go/internal/output"
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/spf13/cobra"
+)
+
+// Flags identifying an agent
+type agentFlags struct {
+	mbox            string
+	mboxSHA1Sum     string
+	openID          string
+	accountHomePage string
+	accountName     string
+}
+
+// Registers the agent flags with the given prefix
+func (f *agentFlags) register(cmd *cobra.Command, prefix string, usage string) {
+	cmd.Flags().StringVar(&f.mbox, prefix+"-mbox", "", usage+" mailbox")
+	cmd.Flags().StringVar(&f.mboxSHA1Sum, prefix+"-sha1", "", usage+" mailbox SHA1")
+	cmd.Flags().StringVar(&f.openID, prefix+"-openid", "", usage+" OpenID")
+	cmd.Flags().StringVar(&f.accountHomePage, prefix+"-account-homepage", "", usage+" account home page")
+	cmd.Flags().StringVar(&f.accountName, prefix+"-account-name", "", usage+" account name")
+	cmd.MarkFlagsRequiredTogether(prefix+"-account-homepage", prefix+"-account-name")
+	cmd.MarkFlagsMutuallyExclusive(prefix+"-mbox", prefix+"-sha1", prefix+"-openid", prefix+"-account-name")
+}
+
+// Returns the agent described by the flags, or nil if none were given
+func (f *agentFlags) agent() *statement.Agent {
+	switch {
+	case len(f.mbox) > 0:
+		return statement.NewAnonymousAgentWithMbox(f.mbox)
+	case len(f.mboxSHA1Sum) > 0:
+		return statement.NewAnonymousAgentWithSHA1(f.mboxSHA1Sum)
+	case len(f.openID) > 0:
+		return statement.NewAnonymousAgentWithOpenID(f.openID)
+	case len(f.accountName) > 0:
+		return statement.NewAnonymousAgentWithAccount(statement.NewAccount(f.accountHomePage, f.accountName))
+	}
+
+	return nil
+}
+
+// Reads a single statement or an array of statements
+func readStatements(r io.Reader) ([]statement.Statement, bool, error) {
+	b, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read statements: %w", err)
+	}
+
+	b = bytes.TrimSpace(b)
+
+	if len(b) > 0 && b[0] == '[' {
+		var statements []statement.Statement
+
+		if err := json.Unmarshal(b, &statements); err != nil {
+			return nil, false, fmt.Errorf("failed to parse statements: %w", err)
+		}
+
+		return statements, true, nil
+	}
+
+	var stmt statement.Statement
+
+	if err := json.Unmarshal(b, &stmt); err != nil {
+		return nil, false, fmt.Errorf("failed to parse statement: %w", err)
+	}
+
+	return []statement.Statement{stmt}, false, nil
+}
+
+var (
+	queryAgent             agentFlags
+	queryStatementID       string
+	queryVoidedStatementID string
+	queryVerb              string
+	queryActivity          string
+	queryRegistration      string
+	queryRelatedActivities bool
+	queryRelatedAgents     bool
+	querySince             string
+	queryUntil             string
+	queryLimit             int64
+	queryFormat            string
+	queryAttachments       bool
+	queryAscending         bool
+	queryAcceptLanguage    string
+	queryMax               int
+	queryOutput            outputFlags
+	voidActor              agentFlags
+	statementsCmd          = &cobra.Command{
+		Use:   "statements",
+		Short: "Queries, posts and voids statements",
+	}
+	queryStatementsCmd = &cobra.Command{
+		Use:   "query",
+		Short: "Queries statements, following more links until every page is fetched",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := queryOutput.parse(); err != nil {
+				return err
+			}
+
+			params, err := queryParams(cmd)
+
+			if err != nil {
+				return err
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			// Fetching a single statement returns the statement instead of a result
+			if params.StatementID != nil || params.VoidedStatementId != nil {
+				return printSingleStatement(cmd, lrs, params)
+			}
+
+			result, resp, err := lrs.QueryStatements(params)
+
+			if err != nil {
+				return err
+			}
+
+			statements := []statement.Statement{}
+
+			for {
+				if result == nil || resp.Status != 200 {
+					return resp.Err()
+				}
+
+				statements = append(statements, result.Statements...)
+
+				if queryMax > 0 && len(statements) >= queryMax {
+					statements = statements[:queryMax]
+					break
+				}
+
+				if len(result.More) == 0 {
+					break
+				}
+
+				result, resp, err = lrs.MoreStatements(result.More)
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return queryOutput.write(cmd, statements)
+		},
+	}
+	postStatementsCmd = &cobra.Command{
+		Use:   "post [FILE]",
+		Short: "Posts a statement or an array of statements read from a file, or stdin if omitted or -",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := cmd.InOrStdin()
+
+			if len(args) == 1 && args[0] != "-" {
+				f, err := os.Open(args[0])
+
+				if err != nil {
+					return err
+				}
+
+				defer f.Close()
+				in = f
+			}
+
+			statements, isArray, err := readStatements(in)
+
+			if err != nil {
+				return err
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			var ids []string
+			var resp *client.Response
+
+			if isArray {
+				ids, resp, err = lrs.SaveStatements(statements)
+			} else {
+				ids, resp, err = lrs.SaveStatement(statements[0])
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if resp.Status >= 300 {
+				return resp.Err()
+			}
+
+			// Statements sent with an id are stored with PUT, which doesn't return ids
+			if ids == nil && statements[0].ID != nil {
+				ids = []string{*statements[0].ID}
+			}
+
+			for _, id := range ids {
+				fmt.Fprintln(cmd.OutOrStdout(), id)
+			}
+
+			return nil
+		},
+	}
+	voidStatementCmd = &cobra.Command{
+		Use:   "void <id>",
+		Short: "Voids a statement",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			actor := voidActor.agent()
+
+			if actor == nil {
+				return errors.New("you have to identify the voiding actor with one of the --actor flags")
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			voiding := statement.NewStatement(
+				actor,
+				*statement.NewVerb(statement.VerbVoided, statement.LanguageMap{"en-US": "voided"}),
+				statement.NewStatementRef(args[0]),
+			)
+
+			ids, resp, err := lrs.SaveStatement(*voiding)
+
+			if err != nil {
+				return err
+			}
+
+			if resp.Status >= 300 {
+				return resp.Err()
+			}
+
+			for _, id := range ids {
+				fmt.Fprintln(cmd.OutOrStdout(), id)
+			}
+
+			return nil
+		},
+	}
+)
+
+// Fetches and prints the statement selected by statementId or voidedStatementId
+func printSingleStatement(cmd *cobra.Command, lrs *client.RemoteLRS, params *client.StatementQueryParams) error {
+	var stmt *statement.Statement
+	var resp *client.Response
+	var err error
+
+	if params.StatementID != nil {
+		stmt, resp, err = lrs.GetStatement(*params.StatementID, &client.GetStatementOptionalParams{
+			Attachments:    params.Attachments,
+			Format:         params.Format,
+			AcceptLanguage: params.AcceptLanguage,
+		})
+	} else {
+		stmt, resp, err = lrs.GetVoidedStatement(*params.VoidedStatementId)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if stmt == nil {
+		return resp.Err()
+	}
+
+	return queryOutput.write(cmd, stmt)
+}
+
+// Builds the query parameters from the flags that were set
+func queryParams(cmd *cobra.Command) (*client.StatementQueryParams, error) {
+	params := client.StatementQueryParams{Agent: queryAgent.agent()}
+	flags := cmd.Flags()
+
+	if flags.Changed("statement-id") {
+		params.StatementID = &queryStatementID
+	}
+
+	if flags.Changed("voided-statement-id") {
+		params.VoidedStatementId = &queryVoidedStatementID
+	}
+
+	if flags.Changed("verb") {
+		params.Verb = &statement.Verb{ID: queryVerb}
+	}
+
+	if flags.Changed("activity") {
+		params.Activity = &statement.Activity{ID: queryActivity}
+	}
+
+	if flags.Changed("registration") {
+		params.Registeration = &queryRegistration
+	}
+
+	if flags.Changed("related-activities") {
+		params.RelatedActivities = &queryRelatedActivities
+	}
+
+	if flags.Changed("related-agents") {
+		params.RelatedAgents = &queryRelatedAgents
+	}
+
+	if flags.Changed("since") {
+		since, err := statement.ParseTimestamp(querySince)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+
+		params.Since = &since.Time
+	}
+
+	if flags.Changed("until") {
+		until, err := statement.ParseTimestamp(queryUntil)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid until: %w", err)
+		}
+
+		params.Until = &until.Time
+	}
+
+	if flags.Changed("limit") {
+		params.Limit = &queryLimit
+	}
+
+	if flags.Changed("format") {
+		if queryFormat != "ids" && queryFormat != "exact" && queryFormat != "canonical" {
+			return nil, errors.New("format must be ids, exact or canonical")
+		}
+
+		params.Format = &queryFormat
+	}
+
+	if flags.Changed("attachments") {
+		params.Attachments = &queryAttachments
+	}
+
+	if flags.Changed("ascending") {
+		params.Ascending = &queryAscending
+	}
+
+	if flags.Changed("accept-language") {
+		params.AcceptLanguage = &queryAcceptLanguage
+	}
+
+	return &params, nil
+}
+
+func init() {
+	queryAgent.register(queryStatementsCmd, "agent", "Agent's")
+	queryStatementsCmd.Flags().StringVar(&queryStatementID, "statement-id", "", "Id of the statement to fetch")
+	queryStatementsCmd.Flags().StringVar(&queryVoidedStatementID, "voided-statement-id", "", "Id of the voided statement to fetch")
+	queryStatementsCmd.Flags().StringVar(&queryVerb, "verb", "", "Verb IRI to filter by")
+	queryStatementsCmd.Flags().StringVar(&queryActivity, "activity", "", "Activity IRI to filter by")
+	queryStatementsCmd.Flags().StringVar(&queryRegistration, "registration", "", "Registration to filter by")
+	queryStatementsCmd.Flags().BoolVar(&queryRelatedActivities, "related-activities", false, "Match the activity anywhere in the statement")
+	queryStatementsCmd.Flags().BoolVar(&queryRelatedAgents, "related-agents", false, "Match the agent anywhere in the statement")
+	queryStatementsCmd.Flags().StringVar(&querySince, "since", "", "Only statements stored after this ISO 8601 timestamp")
+	queryStatementsCmd.Flags().StringVar(&queryUntil, "until", "", "Only statements stored at or before this ISO 8601 timestamp")
+	queryStatementsCmd.Flags().Int64Var(&queryLimit, "limit", 0, "Number of statements fetched per page")
+	queryStatementsCmd.Flags().StringVar(&queryFormat, "format", "", "Statement format: ids, exact or canonical")
+	queryStatementsCmd.Flags().BoolVar(&queryAttachments, "attachments", false, "Include attachment contents")
+	queryStatementsCmd.Flags().BoolVar(&queryAscending, "ascending", false, "Return statements in ascending stored order")
+	queryStatementsCmd.Flags().StringVar(&queryAcceptLanguage, "accept-language", "", "Preferred languages for the canonical format")
+	queryStatementsCmd.Flags().IntVar(&queryMax, "max", 0, "Stop after this many statements, 0 fetches every page")
+	queryOutput.register(queryStatementsCmd, output.StatementFields)
+
+	voidActor.register(voidStatementCmd, "actor", "Voiding actor's")
+
+	statementsCmd.AddCommand(queryStatementsCmd)
+	statementsCmd.AddCommand(postStatementsCmd)
+	statementsCmd.AddCommand(voidStatementCmd)
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/tail"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tailAgent        agentFlags
+	tailActivity     string
+	tailVerb         string
+	tailRegistration string
+	tailSince        string
+	tailInterval     time.Duration
+	tailCmd          = &cobra.Command{
+		Use:   "tail",
+		Short: "Prints new statements as they are stored until interrupted",
+		Long: `Polls the LRS for statements stored since the previous poll and prints each of them on a single line.
+Without --since, only statements stored after the latest one matching the filters are printed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opt := tail.Options{
+				Interval: tailInterval,
+				Logger:   log.New(os.Stderr, "", log.LstdFlags),
+			}
+			opt.Query.Agent = tailAgent.agent()
+
+			if len(tailActivity) > 0 {
+				opt.Query.Activity = &statement.Activity{ID: tailActivity}
+			}
+
+			if len(tailVerb) > 0 {
+				opt.Query.Verb = &statement.Verb{ID: tailVerb}
+			}
+
+			if len(tailRegistration) > 0 {
+				opt.Query.Registeration = &tailRegistration
+			}
+
+			if len(tailSince) > 0 {
+				since, err := statement.ParseTimestamp(tailSince)
+
+				if err != nil {
+					return fmt.Errorf("invalid since: %w", err)
+				}
+
+				opt.Since = &since.Time
+			}
+
+			lrs, err := connect()
+
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return tail.NewTailer(lrs, &opt).Run(ctx, func(stmt statement.Statement) error {
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), compactStatement(stmt))
+				return err
+			})
+		},
+	}
+)
+
+// Formats a statement on a single line, as in: stored actor verb object result
+func compactStatement(stmt statement.Statement) string {
+	var parts []string
+
+	if stmt.Stored != nil {
+		parts = append(parts, stmt.Stored.String())
+	}
+
+	parts = append(parts, compactActor(stmt.Actor))
+
+	if display := stmt.Verb.Display.Best(); len(display) > 0 {
+		parts = append(parts, display)
+	} else {
+		parts = append(parts, stmt.Verb.ID)
+	}
+
+	switch o := stmt.Object.(type) {
+	case *statement.Activity:
+		parts = append(parts, compactActivity(o))
+	case *statement.Agent:
+		parts = append(parts, compactActor(o))
+	case *statement.Group:
+		parts = append(parts, compactActor(o))
+	case *statement.StatementRef:
+		parts = append(parts, "statement "+o.ID)
+	case *statement.SubStatement:
+		parts = append(parts, "sub-statement")
+	}
+
+	if r := stmt.Result; r != nil {
+		if r.Score != nil && r.Score.Scaled != nil {
+			parts = append(parts, "score="+strconv.FormatFloat(*r.Score.Scaled, 'f', -1, 64))
+		} else if r.Score != nil && r.Score.Raw != nil {
+			parts = append(parts, "raw="+strconv.FormatFloat(*r.Score.Raw, 'f', -1, 64))
+		}
+
+		if r.Success != nil {
+			parts = append(parts, "success="+strconv.FormatBool(*r.Success))
+		}
+
+		if r.Completion != nil {
+			parts = append(parts, "completion="+strconv.FormatBool(*r.Completion))
+		}
+
+		if r.Duration != nil {
+			parts = append(parts, "duration="+r.Duration.String())
+		}
+	}
+
+	if stmt.ID != nil {
+		parts = append(parts, "("+*stmt.ID+")")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Formats an actor as its name followed by its IFI
+func compactActor(actor statement.IActor) string {
+	var name *string
+	var ifi string
+
+	switch a := actor.(type) {
+	case *statement.Agent:
+		name = a.Name
+
+		if i, err := a.IFI(); err == nil {
+			ifi = i.String()
+		}
+	case *statement.Group:
+		name = a.Name
+
+		if i, err := a.IFI(); err == nil {
+			ifi = i.String()
+		} else {
+			ifi = fmt.Sprintf("group of %d", len(a.Members))
+		}
+	}
+
+	switch {
+	case name != nil && len(ifi) > 0:
+		return *name + " <" + ifi + ">"
+	case name != nil:
+		return *name
+	default:
+		return "<" + ifi + ">"
+	}
+}
+
+// Formats an activity as its name, or its id when it has none
+func compactActivity(activity *statement.Activity) string {
+	if activity.Definition != nil && activity.Definition.Name != nil {
+		if name := activity.Definition.Name.Best(); len(name) > 0 {
+			return name
+		}
+	}
+
+	return activity.ID
+}
+
+func init() {
+	tailAgent.register(tailCmd, "agent", "Agent's")
+	tailCmd.Flags().StringVar(&tailActivity, "activity", "", "Activity IRI to filter by")
+	tailCmd.Flags().StringVar(&tailVerb, "verb", "", "Verb IRI to filter by")
+	tailCmd.Flags().StringVar(&tailRegistration, "registration", "", "Registration to filter by")
+	tailCmd.Flags().StringVar(&tailSince, "since", "", "Also print statements stored after this ISO 8601 timestamp")
+	tailCmd.Flags().DurationVar(&tailInterval, "interval", 2*time.Second, "Time between polls")
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by the CLI
+const (
+	EnvConfig   = "XAPI_GO_CONFIG"
+	EnvProfile  = "XAPI_GO_PROFILE"
+	EnvEndpoint = "XAPI_GO_ENDPOINT"
+	EnvVersion  = "XAPI_GO_VERSION"
+	EnvUsername = "XAPI_GO_USERNAME"
+	EnvPassword = "XAPI_GO_PASSWORD"
+	EnvAuth     = "XAPI_GO_AUTH"
+)
+
+// Credentials authenticate the CLI against an LRS, either with a username/password pair or an authorization header
+type Credentials struct {
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	Auth     string `yaml:"auth,omitempty"`
+}
+
+// IsZero reports whether no credentials are set
+func (c Credentials) IsZero() bool {
+	return len(c.Username) == 0 && len(c.Password) == 0 && len(c.Auth) == 0
+}
+
+// Profile holds the settings used to connect to an LRS
+type Profile struct {
+	Endpoint    string `yaml:"endpoint"`
+	Version     string `yaml:"version"`
+	Credentials `yaml:",inline"`
+}
+
+// Merge overrides the settings of the profile with the ones set in other. Credentials are replaced as a whole
+// so a username from one source is never paired with an authorization header from another.
+func (p *Profile) Merge(other Profile) {
+	if len(other.Endpoint) > 0 {
+		p.Endpoint = other.Endpoint
+	}
+
+	if len(other.Version) > 0 {
+		p.Version = other.Version
+	}
+
+	if !other.Credentials.IsZero() {
+		p.Credentials = other.Credentials
+	}
+}
+
+// FromEnv returns the profile settings given by environment variables
+func FromEnv() Profile {
+	return Profile{
+		Endpoint: os.Getenv(EnvEndpoint),
+		Version:  os.Getenv(EnvVersion),
+		Credentials: Credentials{
+			Username: os.Getenv(EnvUsername),
+			Password: os.Getenv(EnvPassword),
+			Auth:     os.Getenv(EnvAuth),
+		},
+	}
+}
+
+// Config is the CLI configuration file holding named LRS profiles
+type Config struct {
+	// Profile used when none is selected
+	Default  string              `yaml:"default,omitempty"`
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// DefaultPath returns the configuration file given by XAPI_GO_CONFIG, or config.yaml in the user's configuration directory
+func DefaultPath() (string, error) {
+	if path := os.Getenv(EnvConfig); len(path) > 0 {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		return "", fmt.Errorf("failed to find configuration directory: %w", err)
+	}
+
+	return filepath.Join(dir, "xapi-go", "config.yaml"), nil
+}
+
+// CredentialsPath returns the credentials file stored next to a configuration file
+func CredentialsPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "credentials.yaml")
+}
+
+// Load reads a configuration file. A missing file yields an empty configuration, and a profile left empty,
+// as in "name:", an empty profile.
+func Load(path string) (*Config, error) {
+	c := &Config{}
+
+	if err := readYAML(path, c); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	for name, p := range c.Profiles {
+		if p == nil {
+			c.Profiles[name] = &Profile{}
+		}
+	}
+
+	return c, nil
+}
+
+// Save writes the configuration to the given file
+func (c *Config) Save(path string) error {
+	if err := writeYAML(path, c, 0644); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	return nil
+}
+
+// Names returns the sorted profile names
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Resolve returns a copy of the named profile, or of the default one when name is empty. Profiles without
+// credentials get the ones stored under their name in the credentials file. It returns an empty profile
+// when no profile is selected.
+func (c *Config) Resolve(name string, credentialsPath string) (*Profile, error) {
+	if len(name) == 0 {
+		name = c.Default
+	}
+
+	if len(name) == 0 {
+		return &Profile{}, nil
+	}
+
+	p, ok := c.Profiles[name]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+
+	profile := *p
+
+	if profile.Credentials.IsZero() {
+		credentials, err := LoadCredentials(credentialsPath)
+
+		if err != nil {
+			return nil, err
+		}
+
+		profile.Credentials = credentials[name]
+	}
+
+	return &profile, nil
+}
+
+// LoadCredentials reads a credentials file, keyed by profile name. A missing file yields no credentials.
+// The file is rejected when other users can access it.
+func LoadCredentials(path string) (map[string]Credentials, error) {
+	info, err := os.Stat(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Credentials{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credentials: %w", err)
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("credentials file %s must only be accessible by its owner, its mode is %04o", path, info.Mode().Perm())
+	}
+
+	credentials := map[string]Credentials{}
+
+	if err := readYAML(path, &credentials); err != nil {
+		return nil, fmt.Errorf("failed to load credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// SaveCredentials writes a credentials file only its owner can access
+func SaveCredentials(path string, credentials map[string]Credentials) error {
+	if err := writeYAML(path, credentials, 0600); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+
+	return nil
+}
+
+// Decodes a YAML file, leaving v untouched when the file doesn't exist
+func readYAML(path string, v any) error {
+	b, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(b, v)
+}
+
+// Encodes v to a YAML file with the given permissions, creating its directory when missing
+func writeYAML(path string, v any, perm os.FileMode) error {
+	b, err := yaml.Marshal(v)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return utils.WriteFileAtomic(path, b, perm)
+}
@@ -0,0 +1,477 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+)
+
+// Format is the way records are written
+type Format string
+
+// Output formats
+const (
+	JSON   Format = "json"
+	NDJSON Format = "ndjson"
+	Table  Format = "table"
+	CSV    Format = "csv"
+)
+
+// StatementFields are the fields written by default for statements in the table and CSV formats
+var StatementFields = []string{
+	"id",
+	"actor.name",
+	"actor.ifi",
+	"verb.display",
+	"object.id",
+	"object.definition.name",
+	"result.score.scaled",
+	"result.success",
+	"result.completion",
+	"context.registration",
+	"timestamp",
+}
+
+// ParseFormat returns the format with the given name
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(name); f {
+	case JSON, NDJSON, Table, CSV:
+		return f, nil
+	}
+
+	return "", fmt.Errorf("unknown output format %q, expected json, ndjson, table or csv", name)
+}
+
+// Field selects a value of a record with a JSON-path-like expression. Keys are separated by dots, and keys
+// containing dots or brackets are quoted in brackets, as in result.extensions["http://example.com/ext"].
+// Array elements are selected by index, as in context.contextActivities.parent[0].id. The ifi key of an
+// agent or group which has no such property yields its inverse functional identifier.
+type Field struct {
+	Expression string
+	path       []any
+}
+
+// ParseField parses a field expression
+func ParseField(expr string) (Field, error) {
+	var path []any
+
+	rest := strings.TrimSpace(expr)
+
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+
+			if len(rest) == 0 || rest[0] == '.' || rest[0] == '[' {
+				return Field{}, fmt.Errorf("invalid field %q: empty key", expr)
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+
+			if end < 0 {
+				return Field{}, fmt.Errorf("invalid field %q: unterminated bracket", expr)
+			}
+
+			inner := rest[1:end]
+
+			if len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0] {
+				path = append(path, inner[1:len(inner)-1])
+			} else if i, err := strconv.Atoi(inner); err == nil && i >= 0 {
+				path = append(path, i)
+			} else {
+				return Field{}, fmt.Errorf("invalid field %q: expected an index or a quoted key in brackets", expr)
+			}
+
+			rest = rest[end+1:]
+
+			if len(rest) > 0 && rest[0] != '.' && rest[0] != '[' {
+				return Field{}, fmt.Errorf("invalid field %q: expected a dot or a bracket after ]", expr)
+			}
+
+			continue
+		}
+
+		end := strings.IndexAny(rest, ".[")
+
+		if end < 0 {
+			end = len(rest)
+		}
+
+		path = append(path, rest[:end])
+		rest = rest[end:]
+	}
+
+	if len(path) == 0 {
+		return Field{}, fmt.Errorf("invalid field %q: empty expression", expr)
+	}
+
+	return Field{Expression: expr, path: path}, nil
+}
+
+// ParseFields parses a list of field expressions
+func ParseFields(exprs []string) ([]Field, error) {
+	fields := make([]Field, len(exprs))
+
+	for i, expr := range exprs {
+		f, err := ParseField(expr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fields[i] = f
+	}
+
+	return fields, nil
+}
+
+// FieldList is a flag value holding field expressions. Expressions are separated by commas, except within
+// brackets, and the flag can be repeated.
+type FieldList []string
+
+func (l *FieldList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *FieldList) Set(value string) error {
+	*l = append(*l, SplitFields(value)...)
+	return nil
+}
+
+func (l *FieldList) Type() string {
+	return "fields"
+}
+
+func (l *FieldList) Append(value string) error {
+	return l.Set(value)
+}
+
+func (l *FieldList) Replace(values []string) error {
+	*l = values
+	return nil
+}
+
+func (l *FieldList) GetSlice() []string {
+	return *l
+}
+
+// SplitFields splits a comma separated list of field expressions. Commas within brackets are part of a key.
+func SplitFields(list string) []string {
+	var exprs []string
+	depth := 0
+	start := 0
+
+	for i, c := range list {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				exprs = append(exprs, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(exprs, strings.TrimSpace(list[start:]))
+}
+
+// Value returns the value selected by the field in a record decoded from JSON, or nil when there is none
+func (f Field) Value(record any) any {
+	value := record
+
+	for _, segment := range f.path {
+		switch s := segment.(type) {
+		case string:
+			m, ok := value.(map[string]any)
+
+			if !ok {
+				return nil
+			}
+
+			v, ok := m[s]
+
+			if !ok && s == "ifi" {
+				v = ifiValue(m)
+			}
+
+			value = v
+		case int:
+			a, ok := value.([]any)
+
+			if !ok || s >= len(a) {
+				return nil
+			}
+
+			value = a[s]
+		}
+	}
+
+	return value
+}
+
+// Returns the inverse functional identifier of an agent or group, or nil when there is none
+func ifiValue(m map[string]any) any {
+	var agent statement.Agent
+
+	b, err := json.Marshal(m)
+
+	if err != nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &agent); err != nil {
+		return nil
+	}
+
+	ifi, err := agent.IFI()
+
+	if err != nil {
+		return nil
+	}
+
+	return ifi.String()
+}
+
+// Returns a value as a table or CSV cell. Language maps are reduced to a single language.
+func cell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]any:
+		if s, ok := languageValue(v); ok {
+			return s
+		}
+	case []any:
+		cells := make([]string, len(v))
+
+		for i, e := range v {
+			switch e.(type) {
+			case map[string]any, []any:
+				b, _ := json.Marshal(v)
+				return string(b)
+			}
+
+			cells[i] = cell(e)
+		}
+
+		return strings.Join(cells, ",")
+	}
+
+	b, _ := json.Marshal(value)
+
+	return string(b)
+}
+
+// Returns the preferred value of a language map: en-US, then any English variant, then the first language
+func languageValue(m map[string]any) (string, bool) {
+	if len(m) == 0 {
+		return "", false
+	}
+
+	keys := make([]string, 0, len(m))
+
+	for k, v := range m {
+		if _, ok := v.(string); !ok {
+			return "", false
+		}
+
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	if v, ok := m["en-US"]; ok {
+		return v.(string), true
+	}
+
+	for _, k := range keys {
+		if k == "en" || strings.HasPrefix(k, "en-") {
+			return m[k].(string), true
+		}
+	}
+
+	return m[keys[0]].(string), true
+}
+
+// The values selected from a record, marshalled in the order of the fields
+type selection struct {
+	fields []Field
+	values []any
+}
+
+func (s selection) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+
+	for i, f := range s.fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key, err := json.Marshal(f.Expression)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(s.values[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// Converts values to their generic JSON representation so fields can be evaluated on them.
+// It reports whether values held a single record rather than a list.
+func toRecords(values any) ([]any, bool, error) {
+	b, err := json.Marshal(values)
+
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal records: %w", err)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var records any
+
+	if err := d.Decode(&records); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal records: %w", err)
+	}
+
+	if a, ok := records.([]any); ok {
+		return a, false, nil
+	}
+
+	return []any{records}, true, nil
+}
+
+// Write writes values in the given format. A slice is written as a list of records, anything else as a single
+// record. JSON and NDJSON write whole records unless fields are given, in which case each record is reduced to
+// an object holding the selected values keyed by their expression. Table and CSV write a column per field.
+func Write(w io.Writer, format Format, values any, fields []Field) error {
+	records, single, err := toRecords(values)
+
+	if err != nil {
+		return err
+	}
+
+	if len(fields) > 0 && (format == JSON || format == NDJSON) {
+		for i, record := range records {
+			selected := selection{fields: fields, values: make([]any, len(fields))}
+
+			for j, f := range fields {
+				selected.values[j] = f.Value(record)
+			}
+
+			records[i] = selected
+		}
+	}
+
+	switch format {
+	case JSON:
+		e := json.NewEncoder(w)
+		e.SetEscapeHTML(false)
+		e.SetIndent("", "    ")
+
+		if single {
+			return e.Encode(records[0])
+		}
+
+		return e.Encode(records)
+	case NDJSON:
+		e := json.NewEncoder(w)
+		e.SetEscapeHTML(false)
+
+		for _, record := range records {
+			if err := e.Encode(record); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case Table:
+		t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+		for i, f := range fields {
+			if i > 0 {
+				fmt.Fprint(t, "\t")
+			}
+
+			fmt.Fprint(t, strings.ToUpper(f.Expression))
+		}
+
+		fmt.Fprintln(t)
+
+		for _, record := range records {
+			for i, f := range fields {
+				if i > 0 {
+					fmt.Fprint(t, "\t")
+				}
+
+				// Tabs and line breaks would break the columns
+				fmt.Fprint(t, strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(cell(f.Value(record))))
+			}
+
+			fmt.Fprintln(t)
+		}
+
+		return t.Flush()
+	case CSV:
+		c := csv.NewWriter(w)
+		row := make([]string, len(fields))
+
+		for i, f := range fields {
+			row[i] = f.Expression
+		}
+
+		if err := c.Write(row); err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			for i, f := range fields {
+				row[i] = cell(f.Value(record))
+			}
+
+			if err := c.Write(row); err != nil {
+				return err
+			}
+		}
+
+		c.Flush()
+
+		return c.Error()
+	}
+
+	return fmt.Errorf("unknown output format %q", format)
+}
@@ -0,0 +1,124 @@
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+)
+
+// ExportOptions are the options of an export
+type ExportOptions struct {
+	// Only statements stored after this time are exported
+	Since *time.Time
+	// Only statements stored at or before this time are exported
+	Until *time.Time
+	// Number of statements requested per page
+	Limit *int64
+	// Receives progress messages, discarded if nil
+	Logger *log.Logger
+}
+
+// ExportStats summarizes an export
+type ExportStats struct {
+	Statements int
+	Voided     int
+}
+
+// Export writes the statements stored on the LRS to w as newline delimited JSON, oldest first, fetching one page
+// at a time. Queries hide voided statements, so they are written right before the statements voiding them.
+func Export(source *client.RemoteLRS, w io.Writer, params ...*ExportOptions) (*ExportStats, error) {
+	var opt ExportOptions
+
+	if len(params) > 0 && params[0] != nil {
+		opt = *params[0]
+	}
+
+	if opt.Logger == nil {
+		opt.Logger = log.New(io.Discard, "", 0)
+	}
+
+	query := client.StatementQueryParams{
+		Ascending: utils.Ptr(true),
+		Since:     opt.Since,
+		Until:     opt.Until,
+		Limit:     opt.Limit,
+	}
+
+	e := json.NewEncoder(w)
+	stats := &ExportStats{}
+
+	result, resp, err := source.QueryStatements(&query)
+
+	for {
+		if err != nil {
+			return stats, fmt.Errorf("failed to query statements: %w", err)
+		}
+
+		if result == nil || resp.Status != 200 {
+			return stats, fmt.Errorf("failed to query statements: %w", resp.Err())
+		}
+
+		for _, stmt := range result.Statements {
+			if err := exportVoided(source, e, stmt, stats); err != nil {
+				return stats, err
+			}
+
+			if err := e.Encode(stmt); err != nil {
+				return stats, fmt.Errorf("failed to write statement: %w", err)
+			}
+
+			stats.Statements++
+		}
+
+		opt.Logger.Printf("exported %d statements", stats.Statements)
+
+		if len(result.More) == 0 {
+			return stats, nil
+		}
+
+		result, resp, err = source.MoreStatements(result.More)
+	}
+}
+
+// Writes the statement voided by stmt, if any
+func exportVoided(source *client.RemoteLRS, e *json.Encoder, stmt statement.Statement, stats *ExportStats) error {
+	if stmt.Verb.ID != statement.VerbVoided {
+		return nil
+	}
+
+	var id string
+
+	switch ref := stmt.Object.(type) {
+	case *statement.StatementRef:
+		id = ref.ID
+	case statement.StatementRef:
+		id = ref.ID
+	default:
+		return nil
+	}
+
+	voided, resp, err := source.GetVoidedStatement(id)
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch voided statement %s: %w", id, err)
+	}
+
+	// The voided statement may not be stored on this LRS
+	if voided == nil || resp.Status != 200 {
+		return nil
+	}
+
+	if err := e.Encode(voided); err != nil {
+		return fmt.Errorf("failed to write statement: %w", err)
+	}
+
+	stats.Voided++
+
+	return nil
+}
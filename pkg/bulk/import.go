@@ -0,0 +1,381 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+)
+
+// ImportOptions are the options of an import
+type ImportOptions struct {
+	// Number of statements posted per request, defaults to 100
+	BatchSize int
+	// Number of batches posted at the same time, defaults to 1
+	Concurrency int
+	// File used to persist the processed batches between runs. The import always starts from the beginning if empty.
+	ProgressFile string
+	// Receives a Failure per rejected statement as newline delimited JSON, discarded if nil
+	Failures io.Writer
+	// Registry the statements' extensions are validated against, the default registry if nil
+	Registry *statement.ExtensionRegistry
+	// Receives progress messages, discarded if nil
+	Logger *log.Logger
+}
+
+// ImportStats summarizes an import
+type ImportStats struct {
+	Statements int
+	Conflicts  int
+	Skipped    int
+	Failed     int
+}
+
+// Failure describes a statement which couldn't be imported
+type Failure struct {
+	// Line of the statement in the input
+	Line int `json:"line"`
+	// Reason given by the validation or by the LRS
+	Error string `json:"error"`
+	// Statement as found in the input
+	Statement json.RawMessage `json:"statement"`
+}
+
+// Importer posts statements read as newline delimited JSON to an LRS
+type Importer struct {
+	Target  *client.RemoteLRS
+	Options ImportOptions
+}
+
+// A batch of statements read from the input
+type batch struct {
+	index int
+	// Number of lines holding a statement, valid or not
+	size int
+	// Valid statements along with their line and raw input
+	statements []statement.Statement
+	lines      []int
+	raws       []json.RawMessage
+	// Statements which failed validation
+	failures []Failure
+}
+
+// The outcome of posting a batch
+type batchResult struct {
+	index     int
+	imported  int
+	conflicts int
+	failures  []Failure
+	err       error
+}
+
+// NewImporter creates a new importer
+func NewImporter(target *client.RemoteLRS, params ...*ImportOptions) *Importer {
+	i := Importer{Target: target}
+
+	if len(params) > 0 && params[0] != nil {
+		i.Options = *params[0]
+	}
+
+	if i.Options.BatchSize <= 0 {
+		i.Options.BatchSize = 100
+	}
+
+	if i.Options.Concurrency <= 0 {
+		i.Options.Concurrency = 1
+	}
+
+	if i.Options.Failures == nil {
+		i.Options.Failures = io.Discard
+	}
+
+	if i.Options.Logger == nil {
+		i.Options.Logger = log.New(io.Discard, "", 0)
+	}
+
+	return &i
+}
+
+// Run validates the statements read from r and posts the valid ones in batches. Invalid and rejected statements are
+// reported as failures while the import goes on. Statements without an id get one derived from their line and
+// content, so a batch posted again after an interruption doesn't create duplicates.
+func (i *Importer) Run(r io.Reader) (*ImportStats, error) {
+	progress := &Progress{}
+
+	if len(i.Options.ProgressFile) > 0 {
+		var err error
+
+		if progress, err = LoadProgress(i.Options.ProgressFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if progress.BatchSize == 0 {
+		progress.BatchSize = i.Options.BatchSize
+	}
+
+	if progress.BatchSize != i.Options.BatchSize {
+		return nil, fmt.Errorf("progress was recorded with a batch size of %d", progress.BatchSize)
+	}
+
+	stats := &ImportStats{}
+	batches := make(chan *batch)
+	results := make(chan *batchResult)
+	stop := make(chan struct{})
+
+	var skipped int
+	var readErr error
+
+	// The reader skips the batches processed by previous runs while this one records its own
+	resume := Progress{BatchSize: progress.BatchSize, Done: progress.Done, Completed: append([]int(nil), progress.Completed...)}
+
+	go func() {
+		defer close(batches)
+		skipped, readErr = i.read(r, &resume, batches, stop)
+	}()
+
+	var wg sync.WaitGroup
+
+	for n := 0; n < i.Options.Concurrency; n++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for b := range batches {
+				select {
+				case <-stop:
+					continue
+				default:
+				}
+
+				results <- i.post(b)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var err error
+
+	for res := range results {
+		if err != nil {
+			continue
+		}
+
+		if res.err != nil {
+			err = res.err
+			close(stop)
+			continue
+		}
+
+		stats.Statements += res.imported
+		stats.Conflicts += res.conflicts
+		stats.Failed += len(res.failures)
+
+		if err = i.writeFailures(res.failures); err != nil {
+			close(stop)
+			continue
+		}
+
+		progress.Complete(res.index)
+
+		if len(i.Options.ProgressFile) > 0 {
+			if err = progress.Save(i.Options.ProgressFile); err != nil {
+				close(stop)
+				continue
+			}
+		}
+
+		i.Options.Logger.Printf("imported batch %d: %d statements, %d conflicts, %d failures", res.index, res.imported, res.conflicts, len(res.failures))
+	}
+
+	stats.Skipped = skipped
+
+	if err != nil {
+		return stats, err
+	}
+
+	return stats, readErr
+}
+
+// Reads the input into batches, skipping the ones already processed. It returns the number of skipped statements.
+func (i *Importer) read(r io.Reader, progress *Progress, batches chan<- *batch, stop <-chan struct{}) (int, error) {
+	reader := bufio.NewReader(r)
+	skipped := 0
+	line := 0
+	current := &batch{}
+
+	send := func() bool {
+		if progress.Contains(current.index) {
+			skipped += current.size
+		} else {
+			select {
+			case batches <- current:
+			case <-stop:
+				return false
+			}
+		}
+
+		current = &batch{index: current.index + 1}
+
+		return true
+	}
+
+	for {
+		b, err := reader.ReadBytes('\n')
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return skipped, fmt.Errorf("failed to read statements: %w", err)
+		}
+
+		line++
+
+		if raw := bytes.TrimSpace(b); len(raw) > 0 {
+			current.add(line, raw, i.Options.Registry)
+
+			if current.size == i.Options.BatchSize && !send() {
+				return skipped, nil
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	if current.size > 0 {
+		send()
+	}
+
+	return skipped, nil
+}
+
+// Adds a line of the input to the batch, recording it as a failure when it isn't a valid statement
+func (b *batch) add(line int, raw []byte, registry *statement.ExtensionRegistry) {
+	b.size++
+
+	var stmt statement.Statement
+
+	if err := json.Unmarshal(raw, &stmt); err != nil {
+		b.failures = append(b.failures, Failure{Line: line, Error: err.Error(), Statement: invalidJSON(raw)})
+		return
+	}
+
+	if err := stmt.Validate(registry); err != nil {
+		b.failures = append(b.failures, Failure{Line: line, Error: err.Error(), Statement: raw})
+		return
+	}
+
+	if stmt.ID == nil {
+		id := lineID(line, raw)
+		stmt.ID = &id
+	}
+
+	b.statements = append(b.statements, stmt)
+	b.lines = append(b.lines, line)
+	b.raws = append(b.raws, raw)
+}
+
+// Returns the raw input as a JSON string when it isn't valid JSON, so failures stay valid JSON
+func invalidJSON(raw []byte) json.RawMessage {
+	if json.Valid(raw) {
+		return raw
+	}
+
+	b, _ := json.Marshal(string(raw))
+
+	return b
+}
+
+// Returns a name based UUID for a statement without id
+func lineID(line int, raw []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%d:", line)
+	h.Write(raw)
+	sum := h.Sum(nil)
+
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// Posts a batch. LRSs reject a batch as a whole, so the statements of a rejected batch are posted one by one to
+// single out the rejected ones. Server errors abort the import.
+func (i *Importer) post(b *batch) *batchResult {
+	res := &batchResult{index: b.index, failures: b.failures}
+
+	if len(b.statements) == 0 {
+		return res
+	}
+
+	_, resp, err := i.Target.SaveStatements(b.statements)
+
+	if err != nil {
+		res.err = fmt.Errorf("failed to save batch %d: %w", b.index, err)
+		return res
+	}
+
+	if resp.Status == 200 {
+		res.imported = len(b.statements)
+		return res
+	}
+
+	if fatalStatus(resp.Status) {
+		res.err = fmt.Errorf("failed to save batch %d: %w", b.index, resp.Err())
+		return res
+	}
+
+	for j, stmt := range b.statements {
+		_, resp, err := i.Target.SaveStatement(stmt)
+
+		if err != nil {
+			res.err = fmt.Errorf("failed to save statement %s: %w", *stmt.ID, err)
+			return res
+		}
+
+		switch {
+		case resp.Status == 200 || resp.Status == 204:
+			res.imported++
+		case resp.Status == 409:
+			res.conflicts++
+		case fatalStatus(resp.Status):
+			res.err = fmt.Errorf("failed to save statement %s: %w", *stmt.ID, resp.Err())
+			return res
+		default:
+			res.failures = append(res.failures, Failure{Line: b.lines[j], Error: resp.Err().Error(), Statement: b.raws[j]})
+		}
+	}
+
+	return res
+}
+
+// Reports whether a status means the LRS can't take statements at the moment rather than rejecting them
+func fatalStatus(status int) bool {
+	return status == 401 || status == 403 || status == 429 || status >= 500
+}
+
+// Writes failures as newline delimited JSON
+func (i *Importer) writeFailures(failures []Failure) error {
+	e := json.NewEncoder(i.Options.Failures)
+
+	for _, f := range failures {
+		if err := e.Encode(f); err != nil {
+			return fmt.Errorf("failed to write failure: %w", err)
+		}
+	}
+
+	return nil
+}
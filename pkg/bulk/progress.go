@@ -0,0 +1,84 @@
+package bulk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+)
+
+// Progress records the batches of an import which were processed so that it can be resumed
+type Progress struct {
+	// Number of statements per batch, batches are only the same between runs when it doesn't change
+	BatchSize int `json:"batchSize"`
+	// Number of leading batches which were all processed
+	Done int `json:"done"`
+	// Processed batches following the leading ones, batches complete out of order when posted concurrently
+	Completed []int `json:"completed,omitempty"`
+}
+
+// LoadProgress reads the progress from the given file. A missing file yields an empty progress.
+func LoadProgress(path string) (*Progress, error) {
+	b, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return &Progress{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read progress: %w", err)
+	}
+
+	progress := &Progress{}
+
+	if err := json.Unmarshal(b, progress); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// Save writes the progress to the given file
+func (p *Progress) Save(path string) error {
+	b, err := json.Marshal(p)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal progress: %w", err)
+	}
+
+	if err := utils.WriteFileAtomic(path, b, 0600); err != nil {
+		return fmt.Errorf("failed to save progress: %w", err)
+	}
+
+	return nil
+}
+
+// Complete records a batch as processed
+func (p *Progress) Complete(index int) {
+	if p.Contains(index) {
+		return
+	}
+
+	p.Completed = append(p.Completed, index)
+	sort.Ints(p.Completed)
+
+	// Fold the batches following the leading ones into them
+	for len(p.Completed) > 0 && p.Completed[0] == p.Done {
+		p.Done++
+		p.Completed = p.Completed[1:]
+	}
+}
+
+// Contains reports whether a batch was already processed according to the progress
+func (p *Progress) Contains(index int) bool {
+	if index < p.Done {
+		return true
+	}
+
+	i := sort.SearchInts(p.Completed, index)
+
+	return i < len(p.Completed) && p.Completed[i] == index
+}
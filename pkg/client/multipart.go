@@ -0,0 +1,142 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+)
+
+// newStatementBody serializes a statement or a list of statements. If any of the statements carries attachment data
+// the body is encoded as multipart/mixed as required by the spec, otherwise it is plain JSON.
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Communication.md#requirements-for-attachment-statement-batches
+func newStatementBody(v any, stmts []statement.Statement) (string, string, error) {
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	var attachments []statement.Attachment
+
+	seen := make(map[string]bool)
+
+	for _, stmt := range stmts {
+		for _, a := range stmt.Attachments {
+			if len(a.Content) > 0 && !seen[a.SHA2] {
+				seen[a.SHA2] = true
+				attachments = append(attachments, a)
+			}
+		}
+	}
+
+	if len(attachments) == 0 {
+		return string(b), "application/json", nil
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	part, err := w.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json"}})
+
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create part: %w", err)
+	}
+
+	if _, err := part.Write(b); err != nil {
+		return "", "", fmt.Errorf("failed to write part: %w", err)
+	}
+
+	for _, a := range attachments {
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"binary"},
+			"X-Experience-API-Hash":     {a.SHA2},
+		})
+
+		if err != nil {
+			return "", "", fmt.Errorf("failed to create part: %w", err)
+		}
+
+		if _, err := part.Write(a.Content); err != nil {
+			return "", "", fmt.Errorf("failed to write part: %w", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return "", "", fmt.Errorf("failed to close multipart body: %w", err)
+	}
+
+	return body.String(), mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": w.Boundary()}), nil
+}
+
+// bindStatements binds a statement or a statement result. Multipart responses, returned when attachments are requested,
+// are decoded and the attachment data is assigned to the matching attachments.
+func (r *Response) bindStatements(object any) error {
+	mediaType, params, err := mime.ParseMediaType(r.Response.Header.Get("Content-Type"))
+
+	if err != nil || mediaType != "multipart/mixed" {
+		return r.Bind(object)
+	}
+
+	reader := multipart.NewReader(r.Response.Body, params["boundary"])
+
+	part, err := reader.NextPart()
+
+	if err != nil {
+		return fmt.Errorf("failed to read statement part: %w", err)
+	}
+
+	if err := json.NewDecoder(part).Decode(object); err != nil {
+		return err
+	}
+
+	contents := make(map[string][]byte)
+
+	for {
+		part, err := reader.NextPart()
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read attachment part: %w", err)
+		}
+
+		b, err := io.ReadAll(part)
+
+		if err != nil {
+			return fmt.Errorf("failed to read attachment part: %w", err)
+		}
+
+		contents[part.Header.Get("X-Experience-API-Hash")] = b
+	}
+
+	var stmts []*statement.Statement
+
+	switch v := object.(type) {
+	case *statement.Statement:
+		stmts = append(stmts, v)
+	case *statement.StatementResult:
+		for i := range v.Statements {
+			stmts = append(stmts, &v.Statements[i])
+		}
+	}
+
+	for _, stmt := range stmts {
+		for i, a := range stmt.Attachments {
+			if content, ok := contents[a.SHA2]; ok {
+				stmt.Attachments[i].Content = content
+			}
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -42,7 +43,11 @@ func (lrs *RemoteLRS) sendRequest(req *http.Request) (*Response, error) {
 	client := &http.Client{}
 
 	req.Header.Add("X-Experience-API-Version", lrs.Version)
-	req.Header.Add("Content-Type", "application/json")
+
+	// Document resources set their own content type
+	if len(req.Header.Get("Content-Type")) == 0 {
+		req.Header.Add("Content-Type", "application/json")
+	}
 
 	if len(lrs.Auth) > 0 {
 		req.Header.Add("Authorization", lrs.Auth)
@@ -62,23 +67,23 @@ func (lrs *RemoteLRS) sendRequest(req *http.Request) (*Response, error) {
 }
 
 // SaveStatement is used to save a statement to the record store
-func (lrs *RemoteLRS) SaveStatement(statement statement.Statement) ([]string, *Response, error) {
+func (lrs *RemoteLRS) SaveStatement(stmt statement.Statement) ([]string, *Response, error) {
 	lrs_req := lrs.newRequest("POST", "statements", nil, nil, nil)
 
-	if statement.ID != nil && len(*statement.ID) != 0 {
+	if stmt.ID != nil && len(*stmt.ID) != 0 {
 		lrs_req.Method = "PUT"
-		params := map[string]string{"statementId": *statement.ID}
+		params := map[string]string{"statementId": *stmt.ID}
 		lrs_req.QueryParams = &params
 	}
 
-	b, err := json.Marshal(statement)
+	str, contentType, err := newStatementBody(stmt, []statement.Statement{stmt})
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal: %w", err)
+		return nil, nil, err
 	}
 
-	str := string(b)
 	lrs_req.Content = &str
+	lrs_req.Headers = &map[string]string{"Content-Type": contentType}
 
 	req, err := lrs_req.Init()
 
@@ -93,14 +98,14 @@ func (lrs *RemoteLRS) SaveStatement(statement statement.Statement) ([]string, *R
 	}
 
 	// If we used PUT we don't expect a return value
-	if statement.ID != nil {
+	if stmt.ID != nil || resp.Response.StatusCode != 200 {
 		return nil, resp, nil
 	}
 
 	// If we used POST we expect an array of uuid strings
 	var idList []string
 
-	b, err = io.ReadAll(resp.Response.Body)
+	b, err := io.ReadAll(resp.Response.Body)
 
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
@@ -120,14 +125,14 @@ func (lrs *RemoteLRS) SaveStatement(statement statement.Statement) ([]string, *R
 func (lrs *RemoteLRS) SaveStatements(statements []statement.Statement) ([]string, *Response, error) {
 	lrs_req := lrs.newRequest("POST", "statements", nil, nil, nil)
 
-	b, err := json.Marshal(statements)
+	str, contentType, err := newStatementBody(statements, statements)
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("marshaling failed: %w", err)
+		return nil, nil, err
 	}
 
-	str := string(b)
 	lrs_req.Content = &str
+	lrs_req.Headers = &map[string]string{"Content-Type": contentType}
 
 	req, err := lrs_req.Init()
 
@@ -141,9 +146,13 @@ func (lrs *RemoteLRS) SaveStatements(statements []statement.Statement) ([]string
 		return nil, nil, fmt.Errorf("failed to send request: %w", err)
 	}
 
+	if resp.Response.StatusCode != 200 {
+		return nil, resp, nil
+	}
+
 	var idList []string
 
-	b, err = io.ReadAll(resp.Response.Body)
+	b, err := io.ReadAll(resp.Response.Body)
 
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
@@ -159,9 +168,34 @@ func (lrs *RemoteLRS) SaveStatements(statements []statement.Statement) ([]string
 
 }
 
+// GetStatement optional parameters
+type GetStatementOptionalParams struct {
+	Attachments *bool
+	Format      *string
+	// Languages preferred for the canonical format, sent as the Accept-Language header
+	AcceptLanguage *string
+}
+
 // GetStatement is used to fetch a single statement from record store
-func (lrs *RemoteLRS) GetStatement(id string) (*statement.Statement, *Response, error) {
-	lrs_request := lrs.newRequest("GET", "statements", nil, &map[string]string{"statementId": id}, nil)
+func (lrs *RemoteLRS) GetStatement(id string, params ...*GetStatementOptionalParams) (*statement.Statement, *Response, error) {
+	query_params := map[string]string{"statementId": id}
+	headers := make(map[string]string)
+
+	if len(params) > 0 && params[0] != nil {
+		if params[0].Attachments != nil {
+			query_params["attachments"] = strconv.FormatBool(*params[0].Attachments)
+		}
+
+		if params[0].Format != nil {
+			query_params["format"] = *params[0].Format
+		}
+
+		if params[0].AcceptLanguage != nil {
+			headers["Accept-Language"] = *params[0].AcceptLanguage
+		}
+	}
+
+	lrs_request := lrs.newRequest("GET", "statements", &headers, &query_params, nil)
 
 	req, err := lrs_request.Init()
 
@@ -181,7 +215,7 @@ func (lrs *RemoteLRS) GetStatement(id string) (*statement.Statement, *Response,
 
 	statement := &statement.Statement{}
 
-	if err := lrs_resp.Bind(statement); err != nil {
+	if err := lrs_resp.bindStatements(statement); err != nil {
 		return nil, nil, fmt.Errorf("failed to bind response: %w", err)
 	}
 
@@ -210,13 +244,28 @@ func (lrs *RemoteLRS) GetVoidedStatement(id string) (*statement.Statement, *Resp
 
 	statement := &statement.Statement{}
 
-	if err := lrs_resp.Bind(statement); err != nil {
+	if err := lrs_resp.bindStatements(statement); err != nil {
 		return nil, nil, fmt.Errorf("failed to bind response: %w", err)
 	}
 
 	return statement, lrs_resp, nil
 }
 
+// formatTime encodes a time query parameter as an ISO 8601 timestamp
+func formatTime(t time.Time) string {
+	return statement.NewTimestamp(t.UTC()).String()
+}
+
+// formatUntil encodes until as formatTime does, but keeps the digits finer than milliseconds. Flooring them would
+// leave out the statements stored between the floored time and until.
+func formatUntil(t time.Time) string {
+	if t.Equal(t.Truncate(time.Millisecond)) {
+		return formatTime(t)
+	}
+
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
 // QueryParams represents query parameters of a statement
 type StatementQueryParams struct {
 	StatementID       *string
@@ -233,6 +282,8 @@ type StatementQueryParams struct {
 	Format            *string
 	Attachments       *bool
 	Ascending         *bool
+	// Languages preferred for the canonical format, sent as the Accept-Language header (e.g. "fr-CA, en;q=0.8")
+	AcceptLanguage *string
 }
 
 // Map is used to generate a dictionary from a QueryParams object
@@ -240,11 +291,11 @@ func (q *StatementQueryParams) Map() map[string]string {
 	params := make(map[string]string)
 
 	if q.StatementID != nil {
-		params["statementId"] = *q.VoidedStatementId
+		params["statementId"] = *q.StatementID
 	}
 
 	if q.VoidedStatementId != nil {
-		params["voidedStatementId"] = *q.StatementID
+		params["voidedStatementId"] = *q.VoidedStatementId
 	}
 
 	if q.Agent != nil {
@@ -264,7 +315,7 @@ func (q *StatementQueryParams) Map() map[string]string {
 	}
 
 	if q.Registeration != nil {
-		params["registeration"] = *q.Registeration
+		params["registration"] = *q.Registeration
 	}
 
 	if q.RelatedActivities != nil {
@@ -276,11 +327,11 @@ func (q *StatementQueryParams) Map() map[string]string {
 	}
 
 	if q.Since != nil {
-		params["since"] = q.Since.String()
+		params["since"] = formatTime(*q.Since)
 	}
 
 	if q.Until != nil {
-		params["until"] = q.Until.String()
+		params["until"] = formatUntil(*q.Until)
 	}
 
 	if q.Limit != nil {
@@ -309,12 +360,17 @@ func (q *StatementQueryParams) Map() map[string]string {
 func (lrs *RemoteLRS) QueryStatements(params ...*StatementQueryParams) (*statement.StatementResult, *Response, error) {
 
 	var query_params map[string]string
+	headers := make(map[string]string)
 
 	if len(params) > 0 {
 		query_params = params[0].Map()
+
+		if params[0].AcceptLanguage != nil {
+			headers["Accept-Language"] = *params[0].AcceptLanguage
+		}
 	}
 
-	lrs_request := lrs.newRequest("GET", "statements", nil, &query_params, nil)
+	lrs_request := lrs.newRequest("GET", "statements", &headers, &query_params, nil)
 	req, err := lrs_request.Init()
 
 	if err != nil {
@@ -327,15 +383,67 @@ func (lrs *RemoteLRS) QueryStatements(params ...*StatementQueryParams) (*stateme
 		return nil, nil, fmt.Errorf("failed to send request: %w", err)
 	}
 
+	if lrs_resp.Response.StatusCode != 200 {
+		return nil, lrs_resp, nil
+	}
+
 	result := &statement.StatementResult{}
 
-	if err := lrs_resp.Bind(result); err != nil {
+	if err := lrs_resp.bindStatements(result); err != nil {
 		return nil, nil, fmt.Errorf("failed to bin response: %w", err)
 	}
 
 	return result, lrs_resp, nil
 }
 
+// MoreStatements is used to fetch the next page of a statement query using the more IRL of a previous result
+func (lrs *RemoteLRS) MoreStatements(more string) (*statement.StatementResult, *Response, error) {
+	if len(more) == 0 {
+		return nil, nil, errors.New("more can't be empty")
+	}
+
+	base, err := url.Parse(lrs.Endpoint)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	ref, err := url.Parse(more)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse more url: %w", err)
+	}
+
+	lrs_request := Request{
+		Method: "GET",
+		URL:    base.ResolveReference(ref).String(),
+	}
+
+	req, err := lrs_request.Init()
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to init request: %w", err)
+	}
+
+	lrs_resp, err := lrs.sendRequest(req)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if lrs_resp.Response.StatusCode != 200 {
+		return nil, lrs_resp, nil
+	}
+
+	result := &statement.StatementResult{}
+
+	if err := lrs_resp.bindStatements(result); err != nil {
+		return nil, nil, fmt.Errorf("failed to bind response: %w", err)
+	}
+
+	return result, lrs_resp, nil
+}
+
 // About is used to fetch information about the LRS
 func (lrs *RemoteLRS) About() (*about.About, error) {
 	lrs_request := lrs.newRequest("GET", "about", nil, nil, nil)
@@ -396,7 +504,7 @@ func (lrs *RemoteLRS) GetStateIds(activity statement.Activity, agent statement.A
 		}
 
 		if opt.Since != nil {
-			query_params["since"] = opt.Since.Format(time.RFC3339Nano)
+			query_params["since"] = formatTime(*opt.Since)
 		}
 	}
 
@@ -497,9 +605,7 @@ func (lrs *RemoteLRS) GetState(activity statement.Activity, agent statement.Agen
 	}
 
 	if opt != nil {
-		if opt.Registration != nil {
-			query_params["registration"] = *opt.Registration
-		}
+		doc.Registration = opt.Registration
 	}
 
 	if ts := lrs_resp.Response.Header.Get("last-modified"); len(ts) > 0 {
@@ -548,6 +654,10 @@ func (lrs *RemoteLRS) SaveState(state *documents.StateDocument) (*documents.Stat
 	query_params["stateId"] = state.ID
 	query_params["agent"] = state.Agent.ToJSON()
 
+	if state.Registration != nil {
+		query_params["registration"] = *state.Registration
+	}
+
 	lrs_request := lrs.newRequest("PUT", "activities/state", &headers, &query_params, &content)
 
 	req, err := lrs_request.Init()
@@ -647,7 +757,7 @@ func (lrs *RemoteLRS) GetActivityProfileIds(activity statement.Activity, params
 
 	if opt != nil {
 		if opt.Since != nil {
-			query_params["since"] = opt.Since.Format(time.RFC3339Nano)
+			query_params["since"] = formatTime(*opt.Since)
 		}
 	}
 
@@ -843,7 +953,7 @@ func (lrs *RemoteLRS) GetAgentProfileIds(agent statement.Agent, params ...*GetAg
 
 	if opt != nil {
 		if opt.Since != nil {
-			query_params["since"] = opt.Since.Format(time.RFC3339Nano)
+			query_params["since"] = formatTime(*opt.Since)
 		}
 	}
 
@@ -75,6 +75,17 @@ func (r *Response) Bind(object any) error {
 	return nil
 }
 
+// Err returns an error describing an unsuccessful response, along with the body the LRS sent
+func (r *Response) Err() error {
+	b, _ := io.ReadAll(r.Response.Body)
+
+	if len(b) > 0 {
+		return fmt.Errorf("lrs responded with %d: %s", r.Status, string(b))
+	}
+
+	return fmt.Errorf("lrs responded with %d", r.Status)
+}
+
 func (r *Response) String() string {
 	str := fmt.Sprintf("CODE: %d\n", r.Status)
 	str += fmt.Sprintf("URL: %s\n", r.Request.URL)
@@ -0,0 +1,709 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/documents"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/google/uuid"
+)
+
+var requirements = []Requirement{
+	{"about", "The about resource lists the supported xAPI versions", checkAbout},
+	{"version-header", "Requests without the version header are rejected and responses have it", checkVersionHeader},
+	{"statement-put", "A statement can be stored with PUT and fetched by its id", checkStatementPut},
+	{"statement-post", "Statements can be stored with POST, which returns their ids in order", checkStatementPost},
+	{"statement-stored", "Stored statements have stored, timestamp and authority set", checkStatementStored},
+	{"statement-invalid", "Invalid statements are rejected with 400", checkStatementInvalid},
+	{"statement-conflict", "Storing a different statement with an existing id is rejected with 409", checkStatementConflict},
+	{"statement-void", "Voided statements are fetched with voidedStatementId and left out of queries", checkStatementVoid},
+	{"query-filters", "Queries filter by agent, verb, activity and registration", checkQueryFilters},
+	{"query-since-until", "Queries filter by stored time with since and until", checkQuerySinceUntil},
+	{"query-order-pagination", "Queries order by stored time and page with limit and more", checkQueryOrderPagination},
+	{"query-consistent-through", "Query responses have the consistent through header", checkQueryConsistentThrough},
+	{"document-state", "State documents can be stored, listed, fetched and deleted", checkDocumentState},
+	{"document-etag", "Documents are written only when If-Match and If-None-Match hold", checkDocumentETag},
+	{"document-profile-conflict", "Overwriting a profile without a precondition is rejected with 409", checkDocumentProfileConflict},
+	{"document-merge", "Posting a JSON document merges it into the existing one", checkDocumentMerge},
+}
+
+func checkAbout(c *checker) error {
+	about, err := c.lrs.About()
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch about: %w", err)
+	}
+
+	for _, v := range about.Version {
+		if strings.HasPrefix(v, "1.0.") {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no 1.0.x version in %v", about.Version)
+}
+
+func checkVersionHeader(c *checker) error {
+	resp, body, err := c.raw("GET", "statements", url.Values{"verb": {c.verb("version").ID}}, map[string]string{"X-Experience-API-Version": ""}, "")
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("request without the version header: %w", unexpectedStatus(resp.StatusCode, body, []int{http.StatusBadRequest}))
+	}
+
+	resp, body, err = c.raw("GET", "statements", url.Values{"verb": {c.verb("version").ID}}, nil, "")
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return unexpectedStatus(resp.StatusCode, body, []int{http.StatusOK})
+	}
+
+	if v := resp.Header.Get("X-Experience-API-Version"); !strings.HasPrefix(v, "1.0") {
+		return fmt.Errorf("expected a 1.0.x version header in the response, got %q", v)
+	}
+
+	return nil
+}
+
+func checkStatementPut(c *checker) error {
+	stmt := c.statement("put", c.activity("put"))
+	stmt.ID = utils.Ptr(uuid.NewString())
+
+	if err := c.put(stmt); err != nil {
+		return err
+	}
+
+	got, err := c.get(*stmt.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if got.ID == nil || *got.ID != *stmt.ID {
+		return fmt.Errorf("fetched a statement with a different id")
+	}
+
+	return c.equivalent(stmt, *got)
+}
+
+func checkStatementPost(c *checker) error {
+	stmts := []statement.Statement{
+		c.statement("post", c.activity("post/1")),
+		c.statement("post", c.activity("post/2")),
+	}
+
+	ids, err := c.post(stmts...)
+
+	if err != nil {
+		return err
+	}
+
+	if len(ids) != len(stmts) {
+		return fmt.Errorf("expected %d ids, got %d", len(stmts), len(ids))
+	}
+
+	for i, id := range ids {
+		got, err := c.get(id)
+
+		if err != nil {
+			return err
+		}
+
+		if err := c.equivalent(stmts[i], *got); err != nil {
+			return fmt.Errorf("statement %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func checkStatementStored(c *checker) error {
+	ids, err := c.post(c.statement("stored", c.activity("stored")))
+
+	if err != nil {
+		return err
+	}
+
+	got, err := c.get(ids[0])
+
+	if err != nil {
+		return err
+	}
+
+	if got.Stored == nil {
+		return fmt.Errorf("stored isn't set")
+	}
+
+	if got.Timestamp == nil {
+		return fmt.Errorf("timestamp isn't set")
+	}
+
+	if got.Authority == nil {
+		return fmt.Errorf("authority isn't set")
+	}
+
+	return nil
+}
+
+func checkStatementInvalid(c *checker) error {
+	agent, _ := json.Marshal(c.agent)
+	invalid := map[string]string{
+		"verb isn't an IRI":    `{"actor": %[1]s, "verb": {"id": "completed"}, "object": {"id": "%[2]s"}}`,
+		"actor is missing":     `{"verb": {"id": "http://adlnet.gov/expapi/verbs/completed"}, "object": {"id": "%[2]s"}}`,
+		"unknown property":     `{"actor": %[1]s, "verb": {"id": "http://adlnet.gov/expapi/verbs/completed"}, "object": {"id": "%[2]s"}, "extra": true}`,
+		"invalid registration": `{"actor": %[1]s, "verb": {"id": "http://adlnet.gov/expapi/verbs/completed"}, "object": {"id": "%[2]s"}, "context": {"registration": "1"}}`,
+	}
+
+	names := make([]string, 0, len(invalid))
+
+	for name := range invalid {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		body := fmt.Sprintf(invalid[name], agent, c.activity("invalid").ID)
+		resp, text, err := c.raw("POST", "statements", nil, nil, body)
+
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			return fmt.Errorf("%s: %w", name, unexpectedStatus(resp.StatusCode, text, []int{http.StatusBadRequest}))
+		}
+	}
+
+	return nil
+}
+
+func checkStatementConflict(c *checker) error {
+	stmt := c.statement("conflict", c.activity("conflict"))
+	stmt.ID = utils.Ptr(uuid.NewString())
+
+	if err := c.put(stmt); err != nil {
+		return err
+	}
+
+	// Storing the same statement again isn't a conflict
+	if err := c.put(stmt); err != nil {
+		return fmt.Errorf("storing the same statement again: %w", err)
+	}
+
+	other := c.statement("conflict", c.activity("conflict/other"))
+	other.ID = stmt.ID
+
+	_, resp, err := c.lrs.SaveStatement(other)
+
+	return expectStatus(resp, err, http.StatusConflict)
+}
+
+func checkStatementVoid(c *checker) error {
+	ids, err := c.post(c.statement("void", c.activity("void")))
+
+	if err != nil {
+		return err
+	}
+
+	voiding := statement.NewStatement(&c.agent, *statement.NewVerb(statement.VerbVoided, statement.LanguageMap{"en-US": "voided"}), statement.NewStatementRef(ids[0]))
+
+	if _, err := c.post(*voiding); err != nil {
+		return fmt.Errorf("failed to void: %w", err)
+	}
+
+	_, resp, err := c.lrs.GetStatement(ids[0])
+
+	if err := expectStatus(resp, err, http.StatusNotFound); err != nil {
+		return fmt.Errorf("fetching the voided statement with statementId: %w", err)
+	}
+
+	_, resp, err = c.lrs.GetVoidedStatement(ids[0])
+
+	if err := expectStatus(resp, err, http.StatusOK); err != nil {
+		return fmt.Errorf("fetching the voided statement with voidedStatementId: %w", err)
+	}
+
+	stmts, _, err := c.query(&client.StatementQueryParams{Verb: c.verb("void")})
+
+	if err != nil {
+		return err
+	}
+
+	if len(stmts) > 0 {
+		return fmt.Errorf("the voided statement is returned by queries")
+	}
+
+	return nil
+}
+
+func checkQueryFilters(c *checker) error {
+	registration := uuid.NewString()
+	other := statement.NewAnonymousAgentWithMbox("conformance-" + uuid.NewString() + "@example.com")
+	parent := c.activity("filters")
+
+	// The first statement matches every filter, the others miss one each
+	matching := c.statement("filters", c.activity("filters/1"))
+	matching.Context = &statement.Context{Registration: &registration, ContextActivities: &statement.ContextActivities{Parent: []statement.Activity{*parent}}}
+
+	otherAgent := matching
+	otherAgent.Actor = other
+
+	otherRegistration := c.statement("filters", c.activity("filters/2"))
+	otherRegistration.Context = &statement.Context{Registration: utils.Ptr(uuid.NewString()), ContextActivities: matching.Context.ContextActivities}
+
+	otherParent := c.statement("filters", c.activity("filters/3"))
+	otherParent.Context = &statement.Context{Registration: &registration}
+
+	ids, err := c.post(matching, otherAgent, otherRegistration, otherParent)
+
+	if err != nil {
+		return err
+	}
+
+	stmts, _, err := c.query(&client.StatementQueryParams{
+		Agent:             &c.agent,
+		Verb:              c.verb("filters"),
+		Activity:          parent,
+		RelatedActivities: utils.Ptr(true),
+		Registeration:     &registration,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if got := statementIds(stmts); len(got) != 1 || got[0] != ids[0] {
+		return fmt.Errorf("expected only %s, got %v", ids[0], got)
+	}
+
+	// Without related_activities, activity matches the object only
+	stmts, _, err = c.query(&client.StatementQueryParams{Verb: c.verb("filters"), Activity: parent})
+
+	if err != nil {
+		return err
+	}
+
+	if len(stmts) > 0 {
+		return fmt.Errorf("activity matched context activities without related_activities")
+	}
+
+	return nil
+}
+
+func checkQuerySinceUntil(c *checker) error {
+	var stored []*statement.Statement
+
+	// Statements are stored one at a time so that their stored times differ
+	for i := 0; i < 2; i++ {
+		ids, err := c.post(c.statement("since-until", c.activity(fmt.Sprintf("since-until/%d", i))))
+
+		if err != nil {
+			return err
+		}
+
+		stmt, err := c.get(ids[0])
+
+		if err != nil {
+			return err
+		}
+
+		if stmt.Stored == nil {
+			return fmt.Errorf("stored isn't set")
+		}
+
+		stored = append(stored, stmt)
+	}
+
+	if !stored[1].Stored.After(stored[0].Stored.Time) {
+		return fmt.Errorf("the second statement wasn't stored after the first")
+	}
+
+	// The stored times are sent as the LRS wrote them, the client would round them to milliseconds
+	stmts, err := c.queryRaw(url.Values{"verb": {c.verb("since-until").ID}, "since": {stored[0].Stored.String()}})
+
+	if err != nil {
+		return err
+	}
+
+	if got := statementIds(stmts); len(got) != 1 || got[0] != *stored[1].ID {
+		return fmt.Errorf("since: expected only %s, got %v", *stored[1].ID, got)
+	}
+
+	stmts, err = c.queryRaw(url.Values{"verb": {c.verb("since-until").ID}, "until": {stored[0].Stored.String()}})
+
+	if err != nil {
+		return err
+	}
+
+	if got := statementIds(stmts); len(got) != 1 || got[0] != *stored[0].ID {
+		return fmt.Errorf("until: expected only %s, got %v", *stored[0].ID, got)
+	}
+
+	return nil
+}
+
+func checkQueryOrderPagination(c *checker) error {
+	var ids []string
+
+	for i := 0; i < 3; i++ {
+		posted, err := c.post(c.statement("pages", c.activity(fmt.Sprintf("pages/%d", i))))
+
+		if err != nil {
+			return err
+		}
+
+		ids = append(ids, posted[0])
+	}
+
+	result, resp, err := c.lrs.QueryStatements(&client.StatementQueryParams{Verb: c.verb("pages"), Limit: utils.Ptr(int64(2))})
+
+	if err := expectStatus(resp, err, http.StatusOK); err != nil {
+		return err
+	}
+
+	if len(result.Statements) != 2 {
+		return fmt.Errorf("expected 2 statements with limit 2, got %d", len(result.Statements))
+	}
+
+	if len(result.More) == 0 {
+		return fmt.Errorf("more isn't set when statements are left")
+	}
+
+	next, resp, err := c.lrs.MoreStatements(result.More)
+
+	if err := expectStatus(resp, err, http.StatusOK); err != nil {
+		return fmt.Errorf("following more: %w", err)
+	}
+
+	got := append(statementIds(result.Statements), statementIds(next.Statements)...)
+	want := []string{ids[2], ids[1], ids[0]}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		return fmt.Errorf("expected the latest first %v, got %v", want, got)
+	}
+
+	stmts, _, err := c.query(&client.StatementQueryParams{Verb: c.verb("pages"), Ascending: utils.Ptr(true)})
+
+	if err != nil {
+		return err
+	}
+
+	if got := statementIds(stmts); strings.Join(got, ",") != strings.Join(ids, ",") {
+		return fmt.Errorf("expected the earliest first with ascending %v, got %v", ids, got)
+	}
+
+	return nil
+}
+
+func checkQueryConsistentThrough(c *checker) error {
+	_, resp, err := c.query(&client.StatementQueryParams{Verb: c.verb("consistent-through")})
+
+	if err != nil {
+		return err
+	}
+
+	header := resp.Response.Header.Get("X-Experience-API-Consistent-Through")
+
+	if len(header) == 0 {
+		return fmt.Errorf("the header is missing")
+	}
+
+	if _, err := statement.ParseTimestamp(header); err != nil {
+		return fmt.Errorf("the header isn't a timestamp: %w", err)
+	}
+
+	return nil
+}
+
+func checkDocumentState(c *checker) error {
+	state := &documents.StateDocument{
+		Document: documents.Document{ID: "state", ContentType: "application/json", Content: []byte(`{"progress": 1}`)},
+		Activity: *c.activity("state"),
+		Agent:    c.agent,
+	}
+
+	_, resp, err := c.lrs.SaveState(state)
+
+	if err := expectStatus(resp, err, http.StatusNoContent); err != nil {
+		return fmt.Errorf("saving: %w", err)
+	}
+
+	got, resp, err := c.lrs.GetState(state.Activity, state.Agent, state.ID)
+
+	if err := expectStatus(resp, err, http.StatusOK); err != nil {
+		return fmt.Errorf("fetching: %w", err)
+	}
+
+	if string(got.Content) != string(state.Content) {
+		return fmt.Errorf("fetched %q instead of %q", got.Content, state.Content)
+	}
+
+	if len(got.Etag) == 0 {
+		return fmt.Errorf("the document has no ETag")
+	}
+
+	ids, resp, err := c.lrs.GetStateIds(state.Activity, state.Agent)
+
+	if err := expectStatus(resp, err, http.StatusOK); err != nil {
+		return fmt.Errorf("listing: %w", err)
+	}
+
+	if len(ids) != 1 || ids[0] != state.ID {
+		return fmt.Errorf("expected the ids [%s], got %v", state.ID, ids)
+	}
+
+	resp, err = c.lrs.DeleteState(&documents.StateDocument{Document: documents.Document{ID: state.ID}, Activity: state.Activity, Agent: state.Agent})
+
+	if err := expectStatus(resp, err, http.StatusNoContent); err != nil {
+		return fmt.Errorf("deleting: %w", err)
+	}
+
+	_, resp, err = c.lrs.GetState(state.Activity, state.Agent, state.ID)
+
+	if err := expectStatus(resp, err, http.StatusNotFound); err != nil {
+		return fmt.Errorf("fetching the deleted document: %w", err)
+	}
+
+	return nil
+}
+
+func checkDocumentETag(c *checker) error {
+	query := url.Values{"activityId": {c.activity("etag").ID}, "profileId": {"etag"}}
+
+	if err := c.expectRaw("PUT", "activities/profile", query, map[string]string{"If-None-Match": "*"}, `{"a": 1}`, http.StatusNoContent); err != nil {
+		return fmt.Errorf("creating with If-None-Match *: %w", err)
+	}
+
+	if err := c.expectRaw("PUT", "activities/profile", query, map[string]string{"If-None-Match": "*"}, `{"a": 2}`, http.StatusPreconditionFailed); err != nil {
+		return fmt.Errorf("overwriting with If-None-Match *: %w", err)
+	}
+
+	if err := c.expectRaw("PUT", "activities/profile", query, map[string]string{"If-Match": `"0000000000000000000000000000000000000000"`}, `{"a": 2}`, http.StatusPreconditionFailed); err != nil {
+		return fmt.Errorf("overwriting with a stale If-Match: %w", err)
+	}
+
+	resp, _, err := c.raw("GET", "activities/profile", query, nil, "")
+
+	if err != nil {
+		return err
+	}
+
+	etag := resp.Header.Get("ETag")
+
+	if len(etag) == 0 {
+		return fmt.Errorf("the document has no ETag")
+	}
+
+	if err := c.expectRaw("PUT", "activities/profile", query, map[string]string{"If-Match": etag}, `{"a": 2}`, http.StatusNoContent); err != nil {
+		return fmt.Errorf("overwriting with the current If-Match: %w", err)
+	}
+
+	return nil
+}
+
+func checkDocumentProfileConflict(c *checker) error {
+	agent, _ := json.Marshal(c.agent)
+	query := url.Values{"agent": {string(agent)}, "profileId": {"conflict-" + uuid.NewString()}}
+
+	if err := c.expectRaw("PUT", "agents/profile", query, nil, `{"a": 1}`, http.StatusNoContent); err != nil {
+		return fmt.Errorf("creating: %w", err)
+	}
+
+	return c.expectRaw("PUT", "agents/profile", query, nil, `{"a": 2}`, http.StatusConflict)
+}
+
+func checkDocumentMerge(c *checker) error {
+	agent, _ := json.Marshal(c.agent)
+	query := url.Values{"activityId": {c.activity("merge").ID}, "agent": {string(agent)}, "stateId": {"merge"}}
+
+	if err := c.expectRaw("POST", "activities/state", query, nil, `{"a": 1, "b": 1}`, http.StatusNoContent); err != nil {
+		return fmt.Errorf("creating: %w", err)
+	}
+
+	if err := c.expectRaw("POST", "activities/state", query, nil, `{"b": 2, "c": 2}`, http.StatusNoContent); err != nil {
+		return fmt.Errorf("merging: %w", err)
+	}
+
+	resp, body, err := c.raw("GET", "activities/state", query, nil, "")
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return unexpectedStatus(resp.StatusCode, body, []int{http.StatusOK})
+	}
+
+	var merged map[string]float64
+
+	if err := json.Unmarshal([]byte(body), &merged); err != nil {
+		return fmt.Errorf("the merged document isn't a JSON object: %w", err)
+	}
+
+	if merged["a"] != 1 || merged["b"] != 2 || merged["c"] != 2 || len(merged) != 3 {
+		return fmt.Errorf(`expected {"a": 1, "b": 2, "c": 2}, got %s`, strings.TrimSpace(body))
+	}
+
+	return nil
+}
+
+// Returns a verb unique to the run
+func (c *checker) verb(name string) *statement.Verb {
+	return statement.NewVerb(c.base+"verbs/"+name, statement.LanguageMap{"en-US": name})
+}
+
+// Stores a statement with its id using PUT
+func (c *checker) put(stmt statement.Statement) error {
+	_, resp, err := c.lrs.SaveStatement(stmt)
+
+	if err := expectStatus(resp, err, http.StatusNoContent); err != nil {
+		return fmt.Errorf("failed to put statement: %w", err)
+	}
+
+	return nil
+}
+
+// Stores statements using POST and returns their ids
+func (c *checker) post(stmts ...statement.Statement) ([]string, error) {
+	ids, resp, err := c.lrs.SaveStatements(stmts)
+
+	if err := expectStatus(resp, err, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to post statements: %w", err)
+	}
+
+	if len(ids) != len(stmts) {
+		return nil, fmt.Errorf("expected %d ids, got %d", len(stmts), len(ids))
+	}
+
+	return ids, nil
+}
+
+// Fetches a statement by its id
+func (c *checker) get(id string) (*statement.Statement, error) {
+	stmt, resp, err := c.lrs.GetStatement(id)
+
+	if err := expectStatus(resp, err, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get statement %s: %w", id, err)
+	}
+
+	return stmt, nil
+}
+
+// Queries statements, following more links until every page is fetched
+func (c *checker) query(q *client.StatementQueryParams) ([]statement.Statement, *client.Response, error) {
+	result, resp, err := c.lrs.QueryStatements(q)
+
+	if err := expectStatus(resp, err, http.StatusOK); err != nil {
+		return nil, nil, fmt.Errorf("failed to query statements: %w", err)
+	}
+
+	first := resp
+	stmts := result.Statements
+
+	for len(result.More) > 0 {
+		result, resp, err = c.lrs.MoreStatements(result.More)
+
+		if err := expectStatus(resp, err, http.StatusOK); err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch more statements: %w", err)
+		}
+
+		stmts = append(stmts, result.Statements...)
+	}
+
+	return stmts, first, nil
+}
+
+// Queries statements with raw query parameters, following more links until every page is fetched
+func (c *checker) queryRaw(query url.Values) ([]statement.Statement, error) {
+	resp, body, err := c.raw("GET", "statements", query, nil, "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query statements: %w", unexpectedStatus(resp.StatusCode, body, []int{http.StatusOK}))
+	}
+
+	result := &statement.StatementResult{}
+
+	if err := json.Unmarshal([]byte(body), result); err != nil {
+		return nil, fmt.Errorf("failed to parse statements: %w", err)
+	}
+
+	stmts := result.Statements
+
+	for len(result.More) > 0 {
+		var resp *client.Response
+
+		result, resp, err = c.lrs.MoreStatements(result.More)
+
+		if err := expectStatus(resp, err, http.StatusOK); err != nil {
+			return nil, fmt.Errorf("failed to fetch more statements: %w", err)
+		}
+
+		stmts = append(stmts, result.Statements...)
+	}
+
+	return stmts, nil
+}
+
+// Returns an error describing how the stored statement differs from the sent one
+func (c *checker) equivalent(sent statement.Statement, stored statement.Statement) error {
+	// The LRS sets the id and timestamp when they're left out
+	if sent.ID == nil {
+		sent.ID = stored.ID
+	}
+
+	if sent.Timestamp == nil {
+		sent.Timestamp = stored.Timestamp
+	}
+
+	if ok, diffs := statement.Equivalent(sent, stored); !ok {
+		return fmt.Errorf("the stored statement differs from the sent one: %v", diffs)
+	}
+
+	return nil
+}
+
+// Sends a request without the client and returns an error unless the response has the status
+func (c *checker) expectRaw(method string, resource string, query url.Values, headers map[string]string, body string, status int) error {
+	resp, text, err := c.raw(method, resource, query, headers, body)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != status {
+		return unexpectedStatus(resp.StatusCode, text, []int{status})
+	}
+
+	return nil
+}
+
+// Returns the ids of statements
+func statementIds(stmts []statement.Statement) []string {
+	ids := make([]string, len(stmts))
+
+	for i, stmt := range stmts {
+		if stmt.ID != nil {
+			ids[i] = *stmt.ID
+		}
+	}
+
+	return ids
+}
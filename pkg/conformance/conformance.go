@@ -0,0 +1,201 @@
+package conformance
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/google/uuid"
+)
+
+// Requirement is a behavior the xAPI specification requires from an LRS
+type Requirement struct {
+	ID          string
+	Description string
+	check       func(c *checker) error
+}
+
+// Result is the outcome of checking a requirement
+type Result struct {
+	Requirement string `json:"requirement"`
+	Description string `json:"description"`
+	Passed      bool   `json:"passed"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Options of a conformance run
+type Options struct {
+	// Ids of the requirements to check, every requirement when empty
+	Requirements []string
+	// Receives the result of each requirement, discarded if nil
+	Logger *log.Logger
+}
+
+// Requirements returns every requirement in the order they are checked
+func Requirements() []Requirement {
+	return append([]Requirement(nil), requirements...)
+}
+
+// Run checks the requirements against the LRS. Checks store statements and documents about activities and agents
+// unique to the run, so they don't interfere with existing data, but the LRS should still be a test instance.
+func Run(lrs *client.RemoteLRS, params ...*Options) ([]Result, error) {
+	var opt Options
+
+	if len(params) > 0 && params[0] != nil {
+		opt = *params[0]
+	}
+
+	if opt.Logger == nil {
+		opt.Logger = log.New(io.Discard, "", 0)
+	}
+
+	selected := requirements
+
+	if len(opt.Requirements) > 0 {
+		selected = nil
+
+		for _, id := range opt.Requirements {
+			r, ok := findRequirement(id)
+
+			if !ok {
+				return nil, fmt.Errorf("unknown requirement %s", id)
+			}
+
+			selected = append(selected, r)
+		}
+	}
+
+	run := uuid.NewString()
+	c := &checker{
+		lrs:   lrs,
+		base:  "http://xapi-go.example.com/conformance/" + run + "/",
+		agent: *statement.NewAnonymousAgentWithMbox("conformance-" + run + "@example.com"),
+	}
+
+	results := make([]Result, len(selected))
+
+	for i, r := range selected {
+		results[i] = Result{Requirement: r.ID, Description: r.Description, Passed: true}
+
+		if err := r.check(c); err != nil {
+			results[i].Passed = false
+			results[i].Error = err.Error()
+		}
+
+		opt.Logger.Printf("%s: %t", r.ID, results[i].Passed)
+	}
+
+	return results, nil
+}
+
+// Returns the requirement with the given id
+func findRequirement(id string) (Requirement, bool) {
+	for _, r := range requirements {
+		if r.ID == id {
+			return r, true
+		}
+	}
+
+	return Requirement{}, false
+}
+
+// Holds what checks share: the LRS, along with the activities and agent of the run
+type checker struct {
+	lrs   *client.RemoteLRS
+	base  string
+	agent statement.Agent
+}
+
+// Returns an activity unique to the run
+func (c *checker) activity(name string) *statement.Activity {
+	return statement.NewActivity(c.base + name)
+}
+
+// Returns a statement by the agent of the run with a verb of the run about the activity
+func (c *checker) statement(verb string, activity *statement.Activity) statement.Statement {
+	agent := c.agent
+	return *statement.NewStatement(&agent, *c.verb(verb), activity)
+}
+
+// Sends a request without the client, so that headers the client always sets can be left out or changed
+func (c *checker) raw(method string, resource string, query url.Values, headers map[string]string, body string) (*http.Response, string, error) {
+	u := c.lrs.Endpoint + resource
+
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, u, strings.NewReader(body))
+
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-Experience-API-Version", c.lrs.Version)
+	req.Header.Set("Authorization", c.lrs.Auth)
+
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	for k, v := range headers {
+		if len(v) == 0 {
+			req.Header.Del(k)
+		} else {
+			req.Header.Set(k, v)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(resp.Body)
+
+	return resp, string(b), nil
+}
+
+// Returns an error unless the response has one of the given statuses
+func expectStatus(resp *client.Response, err error, statuses ...int) error {
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		if resp.Status == status {
+			return nil
+		}
+	}
+
+	b, _ := io.ReadAll(resp.Response.Body)
+
+	return unexpectedStatus(resp.Status, string(b), statuses)
+}
+
+// Describes a response with a status other than the expected ones
+func unexpectedStatus(status int, body string, expected []int) error {
+	want := make([]string, len(expected))
+
+	for i, s := range expected {
+		want[i] = fmt.Sprint(s)
+	}
+
+	if body = strings.TrimSpace(body); len(body) > 200 {
+		body = body[:200] + "..."
+	}
+
+	if len(body) > 0 {
+		return fmt.Errorf("expected status %s, got %d: %s", strings.Join(want, " or "), status, body)
+	}
+
+	return fmt.Errorf("expected status %s, got %d", strings.Join(want, " or "), status)
+}
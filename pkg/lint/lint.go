@@ -0,0 +1,277 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+)
+
+// A schema lists the properties an object may hold along with the schemas of their values, nil for values which
+// aren't checked
+type schema struct {
+	properties map[string]*schema
+	// Picks the schema of an object by its properties, for objects whose type decides them
+	choose func(object map[string]any) *schema
+}
+
+// Returns properties whose values aren't checked
+func leaves(names ...string) map[string]*schema {
+	properties := make(map[string]*schema, len(names))
+
+	for _, name := range names {
+		properties[name] = nil
+	}
+
+	return properties
+}
+
+var (
+	accountSchema    = &schema{properties: leaves("homePage", "name")}
+	agentSchema      = &schema{properties: leaves("objectType", "name", "mbox", "mbox_sha1sum", "openid", "account")}
+	groupSchema      = &schema{properties: leaves("objectType", "name", "mbox", "mbox_sha1sum", "openid", "account", "members")}
+	actorSchema      = &schema{}
+	verbSchema       = &schema{properties: leaves("id", "display")}
+	componentSchema  = &schema{properties: leaves("id", "description")}
+	definitionSchema = &schema{properties: leaves("name", "description", "type", "moreInfo", "interactionType", "correctResponsesPattern", "choices", "scale", "source", "target", "steps", "extensions")}
+	activitySchema   = &schema{properties: leaves("objectType", "id", "definition")}
+	refSchema        = &schema{properties: leaves("objectType", "id")}
+	subSchema        = &schema{properties: leaves("objectType", "actor", "verb", "object", "result", "context", "timestamp", "attachments")}
+	objectSchema     = &schema{}
+	scoreSchema      = &schema{properties: leaves("scaled", "raw", "min", "max")}
+	resultSchema     = &schema{properties: leaves("score", "success", "completion", "response", "duration", "extensions")}
+	activitiesSchema = &schema{properties: leaves("parent", "grouping", "category", "other")}
+	contextSchema    = &schema{properties: leaves("registration", "instructor", "team", "contextActivities", "revision", "platform", "language", "statement", "extensions")}
+	attachmentSchema = &schema{properties: leaves("usageType", "display", "description", "contentType", "length", "sha2", "fileUrl")}
+	statementSchema  = &schema{properties: leaves("id", "actor", "verb", "object", "result", "context", "timestamp", "stored", "authority", "version", "attachments")}
+)
+
+func init() {
+	agentSchema.properties["account"] = accountSchema
+	groupSchema.properties["account"] = accountSchema
+	groupSchema.properties["members"] = agentSchema
+
+	actorSchema.choose = func(object map[string]any) *schema {
+		if object["objectType"] == "Group" {
+			return groupSchema
+		}
+
+		return agentSchema
+	}
+
+	for _, name := range []string{"choices", "scale", "source", "target", "steps"} {
+		definitionSchema.properties[name] = componentSchema
+	}
+
+	activitySchema.properties["definition"] = definitionSchema
+
+	objectSchema.choose = func(object map[string]any) *schema {
+		switch object["objectType"] {
+		case "Agent":
+			return agentSchema
+		case "Group":
+			return groupSchema
+		case "StatementRef":
+			return refSchema
+		case "SubStatement":
+			return subSchema
+		default:
+			return activitySchema
+		}
+	}
+
+	resultSchema.properties["score"] = scoreSchema
+
+	for name := range activitiesSchema.properties {
+		activitiesSchema.properties[name] = activitySchema
+	}
+
+	contextSchema.properties["instructor"] = actorSchema
+	contextSchema.properties["team"] = groupSchema
+	contextSchema.properties["contextActivities"] = activitiesSchema
+	contextSchema.properties["statement"] = refSchema
+
+	for _, s := range []*schema{subSchema, statementSchema} {
+		s.properties["actor"] = actorSchema
+		s.properties["verb"] = verbSchema
+		s.properties["object"] = objectSchema
+		s.properties["result"] = resultSchema
+		s.properties["context"] = contextSchema
+		s.properties["attachments"] = attachmentSchema
+	}
+
+	statementSchema.properties["authority"] = actorSchema
+}
+
+// Reports the properties of value missing from the schema
+func (s *schema) walk(value any, path string, errs *statement.ValidationErrors) {
+	switch v := value.(type) {
+	case []any:
+		for i, item := range v {
+			s.walk(item, path+"["+strconv.Itoa(i)+"]", errs)
+		}
+	case map[string]any:
+		if s.choose != nil {
+			s = s.choose(v)
+		}
+
+		keys := make([]string, 0, len(v))
+
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			child, ok := s.properties[k]
+
+			if !ok {
+				*errs = append(*errs, statement.ValidationError{Path: join(path, k), Message: "unknown property"})
+			} else if child != nil {
+				child.walk(v[k], join(path, k), errs)
+			}
+		}
+	}
+}
+
+// Lint checks a statement, or an array of statements, for unknown properties, values of the wrong type and
+// every problem reported by Statement.Validate against the given registry. Paths of the problems found in an
+// array are prefixed with the index of the statement. It only returns an error when data isn't valid JSON.
+func Lint(data []byte, registry ...*statement.ExtensionRegistry) (statement.ValidationErrors, error) {
+	var statements []json.RawMessage
+
+	trimmed := bytes.TrimSpace(data)
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := decode(data, &statements); err != nil {
+			return nil, err
+		}
+	} else {
+		var raw json.RawMessage
+
+		if err := decode(data, &raw); err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, raw)
+	}
+
+	var errs statement.ValidationErrors
+
+	for i, raw := range statements {
+		prefix := ""
+
+		if len(trimmed) > 0 && trimmed[0] == '[' {
+			prefix = "[" + strconv.Itoa(i) + "]"
+		}
+
+		errs = append(errs, lintStatement(raw, prefix, registry)...)
+	}
+
+	return errs, nil
+}
+
+// Checks a single statement
+func lintStatement(raw json.RawMessage, prefix string, registry []*statement.ExtensionRegistry) statement.ValidationErrors {
+	var errs statement.ValidationErrors
+
+	root := prefix
+
+	if len(root) == 0 {
+		root = "statement"
+	}
+
+	var value any
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+
+	// The raw message was already decoded once, so it is valid JSON
+	d.Decode(&value)
+
+	if _, ok := value.(map[string]any); !ok {
+		return append(errs, statement.ValidationError{Path: root, Message: "not an object"})
+	}
+
+	statementSchema.walk(value, prefix, &errs)
+
+	stmt := statement.Statement{}
+
+	if err := json.Unmarshal(raw, &stmt); err != nil {
+		var typeErr *json.UnmarshalTypeError
+
+		if errors.As(err, &typeErr) && len(typeErr.Field) > 0 {
+			return append(errs, statement.ValidationError{Path: join(prefix, typeErr.Field), Message: "unexpected " + typeErr.Value})
+		}
+
+		return append(errs, statement.ValidationError{Path: root, Message: err.Error()})
+	}
+
+	var validationErrs statement.ValidationErrors
+
+	if err := stmt.Validate(registry...); errors.As(err, &validationErrs) {
+		for _, e := range validationErrs {
+			errs = append(errs, statement.ValidationError{Path: join(prefix, e.Path), Message: e.Message})
+		}
+	}
+
+	return errs
+}
+
+// Decodes data as a single JSON value, reporting the line and column of syntax errors
+func decode(data []byte, v any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+
+	if err := d.Decode(v); err != nil {
+		var syntaxErr *json.SyntaxError
+
+		if errors.As(err, &syntaxErr) {
+			line, column := position(data, syntaxErr.Offset-1)
+			return fmt.Errorf("invalid JSON at line %d, column %d: %w", line, column, err)
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("invalid JSON: unexpected end of input")
+		}
+
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	end := d.InputOffset()
+
+	if _, err := d.Token(); !errors.Is(err, io.EOF) {
+		end += int64(len(data[end:]) - len(bytes.TrimLeft(data[end:], " \t\r\n")))
+		line, column := position(data, end)
+		return fmt.Errorf("invalid JSON at line %d, column %d: unexpected data after the top-level value", line, column)
+	}
+
+	return nil
+}
+
+// Returns the line and column of the byte at the given offset, both starting at 1
+func position(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+
+	return line, column
+}
+
+// Joins a property to a path
+func join(path string, property string) string {
+	if len(path) == 0 {
+		return property
+	}
+
+	return path + "." + property
+}
@@ -0,0 +1,339 @@
+package lrs
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/google/uuid"
+)
+
+// A document resource along with the parameters scoping its documents
+type documentResource struct {
+	name         string
+	idParam      string
+	activity     bool
+	agent        bool
+	registration bool
+	// Writing over an existing document requires a precondition
+	preconditions bool
+	// Deleting without an id deletes every document of the scope
+	deleteAll bool
+}
+
+var (
+	stateResource           = &documentResource{name: "state", idParam: "stateId", activity: true, agent: true, registration: true, deleteAll: true}
+	activityProfileResource = &documentResource{name: "activity profile", idParam: "profileId", activity: true, preconditions: true}
+	agentProfileResource    = &documentResource{name: "agent profile", idParam: "profileId", agent: true, preconditions: true}
+)
+
+// A stored document
+type document struct {
+	Content     []byte    `json:"content"`
+	ContentType string    `json:"contentType"`
+	ETag        string    `json:"etag"`
+	Updated     time.Time `json:"updated"`
+}
+
+// Returns the key of the scope of the documents a request targets, along with the error of invalid parameters
+func (res *documentResource) scope(q url.Values) (string, error) {
+	parts := []string{res.name}
+
+	if res.activity {
+		activityID := q.Get("activityId")
+
+		if u, err := url.Parse(activityID); err != nil || !u.IsAbs() {
+			return "", statusError{http.StatusBadRequest, "activityId must be an IRI"}
+		}
+
+		parts = append(parts, activityID)
+	}
+
+	if res.agent {
+		var actor statement.IActor
+
+		if err := statement.UnmarshalActor(json.RawMessage(q.Get("agent")), &actor); err != nil {
+			return "", statusError{http.StatusBadRequest, "invalid agent"}
+		}
+
+		key := actorKey(actor)
+
+		if len(key) == 0 {
+			return "", statusError{http.StatusBadRequest, "agent must have an inverse functional identifier"}
+		}
+
+		parts = append(parts, key)
+	}
+
+	if res.registration {
+		registration := strings.ToLower(q.Get("registration"))
+
+		if _, err := uuid.Parse(registration); len(registration) > 0 && err != nil {
+			return "", statusError{http.StatusBadRequest, "registration must be a UUID"}
+		}
+
+		parts = append(parts, registration)
+	}
+
+	return strings.Join(parts, "\x00") + "\x00", nil
+}
+
+func (l *LRS) serveDocuments(w http.ResponseWriter, r *http.Request, res *documentResource) {
+	q := r.URL.Query()
+	scope, err := res.scope(q)
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	id := q.Get(res.idParam)
+
+	switch r.Method {
+	case "GET":
+		if len(id) == 0 {
+			l.listDocuments(w, q, scope)
+		} else {
+			l.getDocument(w, scope+id)
+		}
+	case "PUT", "POST":
+		if len(id) == 0 {
+			httpError(w, http.StatusBadRequest, res.idParam+" is required")
+			return
+		}
+
+		if err := l.saveDocument(r, res, scope+id); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	case "DELETE":
+		if len(id) == 0 && !res.deleteAll {
+			httpError(w, http.StatusBadRequest, res.idParam+" is required")
+			return
+		}
+
+		if err := l.deleteDocuments(r, scope, id); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		httpError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (l *LRS) getDocument(w http.ResponseWriter, key string) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	doc := l.documents[key]
+
+	if doc == nil {
+		httpError(w, http.StatusNotFound, "document not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("ETag", doc.ETag)
+	w.Header().Set("Last-Modified", doc.Updated.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusOK)
+	w.Write(doc.Content)
+}
+
+// Lists the ids of the documents of a scope, updated after since if given
+func (l *LRS) listDocuments(w http.ResponseWriter, q url.Values, scope string) {
+	var since *time.Time
+
+	if q.Has("since") {
+		ts, err := statement.ParseTimestamp(q.Get("since"))
+
+		if err != nil {
+			httpError(w, http.StatusBadRequest, "invalid since")
+			return
+		}
+
+		since = &ts.Time
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ids := []string{}
+
+	for key, doc := range l.documents {
+		if strings.HasPrefix(key, scope) && (since == nil || doc.Updated.After(*since)) {
+			ids = append(ids, strings.TrimPrefix(key, scope))
+		}
+	}
+
+	sort.Strings(ids)
+	writeJSON(w, http.StatusOK, ids)
+}
+
+// Saves a document with PUT, or merges it into the existing JSON document with POST
+func (l *LRS) saveDocument(r *http.Request, res *documentResource, key string) error {
+	content, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+
+	contentType := r.Header.Get("Content-Type")
+
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	existing := l.documents[key]
+
+	if err := checkPreconditions(r, existing); err != nil {
+		return err
+	}
+
+	if r.Method == "PUT" && res.preconditions && existing != nil && len(r.Header.Get("If-Match")) == 0 && len(r.Header.Get("If-None-Match")) == 0 {
+		return statusError{http.StatusConflict, "the document exists, send If-Match or If-None-Match to overwrite it"}
+	}
+
+	if r.Method == "POST" && existing != nil {
+		if content, err = mergeJSON(existing, content, contentType); err != nil {
+			return err
+		}
+
+		contentType = existing.ContentType
+	}
+
+	sum := sha1.Sum(content)
+
+	l.documents[key] = &document{
+		Content:     content,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:]) + `"`,
+		Updated:     time.Now().UTC(),
+	}
+
+	if err := l.persist(); err != nil {
+		if existing == nil {
+			delete(l.documents, key)
+		} else {
+			l.documents[key] = existing
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Merges the properties of a JSON object into the existing JSON document
+func mergeJSON(existing *document, content []byte, contentType string) ([]byte, error) {
+	if !isJSON(existing.ContentType) || !isJSON(contentType) {
+		return nil, statusError{http.StatusBadRequest, "only JSON documents can be merged"}
+	}
+
+	var merged, update map[string]any
+
+	if err := json.Unmarshal(existing.Content, &merged); err != nil || merged == nil {
+		return nil, statusError{http.StatusBadRequest, "the existing document isn't a JSON object"}
+	}
+
+	if err := json.Unmarshal(content, &update); err != nil || update == nil {
+		return nil, statusError{http.StatusBadRequest, "the document isn't a JSON object"}
+	}
+
+	for k, v := range update {
+		merged[k] = v
+	}
+
+	return json.Marshal(merged)
+}
+
+// Reports whether a content type is JSON
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+// Deletes a document, or every document of the scope when id is empty
+func (l *LRS) deleteDocuments(r *http.Request, scope string, id string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	removed := make(map[string]*document)
+
+	if len(id) > 0 {
+		if err := checkPreconditions(r, l.documents[scope+id]); err != nil {
+			return err
+		}
+
+		if doc := l.documents[scope+id]; doc != nil {
+			removed[scope+id] = doc
+		}
+	} else {
+		for key, doc := range l.documents {
+			if strings.HasPrefix(key, scope) {
+				removed[key] = doc
+			}
+		}
+	}
+
+	for key := range removed {
+		delete(l.documents, key)
+	}
+
+	if err := l.persist(); err != nil {
+		for key, doc := range removed {
+			l.documents[key] = doc
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Checks the If-Match and If-None-Match headers against the existing document
+func checkPreconditions(r *http.Request, existing *document) error {
+	if ifMatch := r.Header.Get("If-Match"); len(ifMatch) > 0 {
+		if existing == nil || !etagMatches(ifMatch, existing.ETag) {
+			return statusError{http.StatusPreconditionFailed, "If-Match doesn't match the document"}
+		}
+	}
+
+	if ifNoneMatch := r.Header.Get("If-None-Match"); len(ifNoneMatch) > 0 {
+		if existing != nil && etagMatches(ifNoneMatch, existing.ETag) {
+			return statusError{http.StatusPreconditionFailed, "If-None-Match matches the document"}
+		}
+	}
+
+	return nil
+}
+
+// Reports whether a precondition header lists the ETag. Quotes and weak markers are ignored.
+func etagMatches(header string, etag string) bool {
+	etag = strings.Trim(etag, `"`)
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.Trim(strings.TrimPrefix(strings.TrimSpace(candidate), "W/"), `"`)
+
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
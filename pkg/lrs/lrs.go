@@ -0,0 +1,161 @@
+package lrs
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/about"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+)
+
+// Version of the xAPI specification the LRS responds with
+const Version = "1.0.3"
+
+// Versions of the xAPI specification the LRS accepts
+var Versions = []string{"1.0.3", "1.0.2", "1.0.1", "1.0.0"}
+
+// Options of an LRS
+type Options struct {
+	// Maximum number of statements returned per page, defaults to 100
+	PageSize int
+	// Authority set on statements stored without one, defaults to an account on the LRS named after the username
+	Authority *statement.Agent
+	// Basic auth credentials required by every request but about, none are required when the username is empty
+	Username string
+	Password string
+	// Receives internal errors, discarded if nil
+	Logger *log.Logger
+}
+
+// LRS is an in-memory Learning Record Store serving the statement, document and about resources, meant to develop
+// and test against. It implements http.Handler and serves the resources relative to the root of the path.
+type LRS struct {
+	Options Options
+	mu      sync.RWMutex
+	// Statements in stored order
+	statements []*record
+	byID       map[string]*record
+	documents  map[string]*document
+	// Stored time of the latest statement, stored times are unique and increasing
+	lastStored time.Time
+	// File the LRS is persisted to, empty when kept in memory only
+	path string
+}
+
+// A stored statement
+type record struct {
+	Statement statement.Statement `json:"statement"`
+	Voided    bool                `json:"voided,omitempty"`
+}
+
+// NewLRS creates a new empty LRS
+func NewLRS(params ...*Options) *LRS {
+	l := LRS{
+		byID:      make(map[string]*record),
+		documents: make(map[string]*document),
+	}
+
+	if len(params) > 0 && params[0] != nil {
+		l.Options = *params[0]
+	}
+
+	if l.Options.PageSize <= 0 {
+		l.Options.PageSize = 100
+	}
+
+	if l.Options.Authority == nil {
+		name := l.Options.Username
+
+		if len(name) == 0 {
+			name = "xapi-go"
+		}
+
+		l.Options.Authority = statement.NewAnonymousAgentWithAccount(statement.NewAccount("http://localhost/", name))
+	}
+
+	if l.Options.Logger == nil {
+		l.Options.Logger = log.New(io.Discard, "", 0)
+	}
+
+	return &l
+}
+
+// ServeHTTP routes a request to its resource
+func (l *LRS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Experience-API-Version", Version)
+
+	resource := strings.Trim(r.URL.Path, "/")
+
+	if resource == "about" {
+		if r.Method != "GET" {
+			httpError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, about.About{Version: Versions})
+		return
+	}
+
+	if !l.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="xapi-go"`)
+		httpError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	if !supportedVersion(r.Header.Get("X-Experience-API-Version")) {
+		httpError(w, http.StatusBadRequest, "missing or unsupported X-Experience-API-Version header")
+		return
+	}
+
+	switch resource {
+	case "statements":
+		l.serveStatements(w, r)
+	case "activities/state":
+		l.serveDocuments(w, r, stateResource)
+	case "activities/profile":
+		l.serveDocuments(w, r, activityProfileResource)
+	case "agents/profile":
+		l.serveDocuments(w, r, agentProfileResource)
+	default:
+		httpError(w, http.StatusNotFound, "unknown resource")
+	}
+}
+
+// Reports whether a request has the credentials of the LRS, if it requires any
+func (l *LRS) authorized(r *http.Request) bool {
+	if len(l.Options.Username) == 0 {
+		return true
+	}
+
+	username, password, ok := r.BasicAuth()
+
+	return ok &&
+		subtle.ConstantTimeCompare([]byte(username), []byte(l.Options.Username)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(password), []byte(l.Options.Password)) == 1
+}
+
+// Reports whether a version header is one of the accepted versions. Patch versions are compatible.
+func supportedVersion(v string) bool {
+	return strings.HasPrefix(v, "1.0.") || v == "1.0"
+}
+
+// Writes a plain text error
+func httpError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintln(w, message)
+}
+
+// Writes a value as JSON
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
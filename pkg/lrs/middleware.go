@@ -0,0 +1,66 @@
+package lrs
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Headers browsers are allowed to read from responses
+var exposedHeaders = []string{"Content-Type", "ETag", "Last-Modified", "X-Experience-API-Version", "X-Experience-API-Consistent-Through"}
+
+// CORS allows browsers to send requests to the handler from any origin, answering preflight requests itself.
+// Browsers don't send cookies along, requests authenticate with their Authorization header.
+func CORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.Header.Get("Origin")) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(exposedHeaders, ", "))
+
+		if r.Method != "OPTIONS" || len(r.Header.Get("Access-Control-Request-Method")) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, POST, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+		w.Header().Set("Access-Control-Max-Age", "86400")
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// Records the status of a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// LogRequests logs the method, URL, status and duration of every request to the handler
+func LogRequests(next http.Handler, logger *log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		target := r.URL.Path
+
+		// Queries are unescaped, agents in particular are unreadable otherwise
+		if query, err := url.QueryUnescape(r.URL.RawQuery); err == nil && len(query) > 0 {
+			target += "?" + query
+		}
+
+		logger.Printf("%s %s %d %s", r.Method, target, recorder.status, time.Since(start).Round(time.Microsecond))
+	})
+}
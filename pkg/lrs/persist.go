@@ -0,0 +1,84 @@
+package lrs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+)
+
+// Contents of the file an LRS is persisted to
+type snapshot struct {
+	Statements []*record            `json:"statements"`
+	Documents  map[string]*document `json:"documents"`
+}
+
+// Open creates an LRS persisted to a file, loading the statements and documents it holds when it exists.
+// The whole LRS is written to the file after every change, which suits the volumes of development and tests.
+func Open(path string, params ...*Options) (*LRS, error) {
+	l := NewLRS(params...)
+	l.path = path
+
+	b, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var s snapshot
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+
+	for _, rec := range s.Statements {
+		if rec.Statement.ID == nil || rec.Statement.Stored == nil {
+			return nil, fmt.Errorf("%s holds a statement without an id or stored time", path)
+		}
+
+		l.statements = append(l.statements, rec)
+		l.byID[*rec.Statement.ID] = rec
+
+		if rec.Statement.Stored.After(l.lastStored) {
+			l.lastStored = rec.Statement.Stored.Time
+		}
+	}
+
+	if s.Documents != nil {
+		l.documents = s.Documents
+	}
+
+	return l, nil
+}
+
+// Writes the LRS to its file, if it has one, logging failures. Must be called with the lock held, and changes
+// rolled back when it fails.
+func (l *LRS) persist() error {
+	if len(l.path) == 0 {
+		return nil
+	}
+
+	if err := l.writeSnapshot(); err != nil {
+		l.Options.Logger.Printf("failed to persist: %s", err)
+		return err
+	}
+
+	return nil
+}
+
+// Writes the LRS to its file
+func (l *LRS) writeSnapshot() error {
+	b, err := json.Marshal(snapshot{Statements: l.statements, Documents: l.documents})
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	return utils.WriteFileAtomic(l.path, b, 0600)
+}
@@ -0,0 +1,650 @@
+package lrs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/lint"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Parameters accepted by the statement resource, the offset is used by more links
+var statementParams = map[string]bool{
+	"statementId": true, "voidedStatementId": true, "agent": true, "verb": true, "activity": true,
+	"registration": true, "related_activities": true, "related_agents": true, "since": true, "until": true,
+	"limit": true, "format": true, "attachments": true, "ascending": true, "offset": true,
+}
+
+// Errors carrying the status a request fails with
+type statusError struct {
+	status  int
+	message string
+}
+
+func (e statusError) Error() string {
+	return e.message
+}
+
+// Writes an error, using its status when it has one
+func writeError(w http.ResponseWriter, err error) {
+	var se statusError
+
+	if errors.As(err, &se) {
+		httpError(w, se.status, se.message)
+		return
+	}
+
+	httpError(w, http.StatusInternalServerError, err.Error())
+}
+
+func (l *LRS) serveStatements(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		l.getStatements(w, r)
+	case "PUT":
+		l.putStatement(w, r)
+	case "POST":
+		l.postStatements(w, r)
+	default:
+		httpError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (l *LRS) putStatement(w http.ResponseWriter, r *http.Request) {
+	id := strings.ToLower(r.URL.Query().Get("statementId"))
+
+	if _, err := uuid.Parse(id); err != nil {
+		httpError(w, http.StatusBadRequest, "statementId must be a UUID")
+		return
+	}
+
+	statements, isArray, err := readStatements(r)
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if isArray {
+		httpError(w, http.StatusBadRequest, "PUT takes a single statement")
+		return
+	}
+
+	if statements[0].ID != nil && !strings.EqualFold(*statements[0].ID, id) {
+		httpError(w, http.StatusBadRequest, "statement id doesn't match statementId")
+		return
+	}
+
+	statements[0].ID = &id
+
+	if _, err := l.store(statements); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (l *LRS) postStatements(w http.ResponseWriter, r *http.Request) {
+	statements, _, err := readStatements(r)
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ids, err := l.store(statements)
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ids)
+}
+
+// Reads and validates a statement or an array of statements. Attachments sent as multipart are ignored.
+func readStatements(r *http.Request) ([]statement.Statement, bool, error) {
+	var body io.Reader = r.Body
+
+	if mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && mediaType == "multipart/mixed" {
+		part, err := multipart.NewReader(r.Body, params["boundary"]).NextPart()
+
+		if err != nil {
+			return nil, false, statusError{http.StatusBadRequest, "failed to read statement part"}
+		}
+
+		body = part
+	}
+
+	data, err := io.ReadAll(body)
+
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	errs, err := lint.Lint(data)
+
+	if err != nil {
+		return nil, false, statusError{http.StatusBadRequest, err.Error()}
+	}
+
+	if len(errs) > 0 {
+		return nil, false, statusError{http.StatusBadRequest, errs.Error()}
+	}
+
+	trimmed := bytes.TrimSpace(data)
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var statements []statement.Statement
+
+		if err := json.Unmarshal(data, &statements); err != nil {
+			return nil, false, statusError{http.StatusBadRequest, err.Error()}
+		}
+
+		return statements, true, nil
+	}
+
+	stmt := statement.Statement{}
+
+	if err := json.Unmarshal(data, &stmt); err != nil {
+		return nil, false, statusError{http.StatusBadRequest, err.Error()}
+	}
+
+	return []statement.Statement{stmt}, false, nil
+}
+
+// Stores statements, all of them or none, and returns their ids. Statements whose id is already stored are
+// skipped when they are equivalent to the stored one, and conflict otherwise.
+func (l *LRS) store(statements []statement.Statement) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ids := make([]string, len(statements))
+	batch := make(map[string]statement.Statement)
+	var added []statement.Statement
+
+	for i, stmt := range statements {
+		if stmt.ID == nil {
+			stmt.ID = utils.Ptr(uuid.NewString())
+		} else {
+			stmt.ID = utils.Ptr(strings.ToLower(*stmt.ID))
+		}
+
+		ids[i] = *stmt.ID
+
+		existing, ok := batch[*stmt.ID]
+
+		if !ok {
+			if rec := l.byID[*stmt.ID]; rec != nil {
+				existing, ok = rec.Statement, true
+			}
+		}
+
+		if ok {
+			// The stored statement got the timestamp from its stored time when sent without one
+			candidate := stmt
+
+			if candidate.Timestamp == nil {
+				candidate.Timestamp = existing.Timestamp
+			}
+
+			if equal, _ := statement.Equivalent(existing, candidate); !equal {
+				return nil, statusError{http.StatusConflict, fmt.Sprintf("statement %s already exists with a different content", *stmt.ID)}
+			}
+
+			continue
+		}
+
+		if target := voidedID(stmt); len(target) > 0 {
+			voided, ok := batch[target]
+
+			if rec := l.byID[target]; rec != nil {
+				voided, ok = rec.Statement, true
+			}
+
+			if ok && len(voidedID(voided)) > 0 {
+				return nil, statusError{http.StatusBadRequest, "voiding statements can't be voided"}
+			}
+		}
+
+		batch[*stmt.ID] = stmt
+		added = append(added, stmt)
+	}
+
+	if len(added) == 0 {
+		return ids, nil
+	}
+
+	count, lastStored := len(l.statements), l.lastStored
+	var voided []*record
+
+	for _, stmt := range added {
+		stored := time.Now().UTC().Truncate(time.Millisecond)
+
+		if !stored.After(l.lastStored) {
+			stored = l.lastStored.Add(time.Millisecond)
+		}
+
+		l.lastStored = stored
+		stmt.Stored = &statement.Timestamp{Time: stored}
+
+		if stmt.Timestamp == nil {
+			stmt.Timestamp = stmt.Stored
+		}
+
+		if stmt.Authority == nil {
+			stmt.Authority = l.Options.Authority
+		}
+
+		if stmt.Version == nil {
+			stmt.Version = utils.Ptr("1.0.0")
+		}
+
+		rec := &record{Statement: stmt}
+		l.statements = append(l.statements, rec)
+		l.byID[*stmt.ID] = rec
+
+		if target := l.byID[voidedID(stmt)]; target != nil && !target.Voided {
+			target.Voided = true
+			voided = append(voided, target)
+		}
+	}
+
+	if err := l.persist(); err != nil {
+		// Rolls the statements back so that the LRS holds what its file does
+		for _, rec := range l.statements[count:] {
+			delete(l.byID, *rec.Statement.ID)
+		}
+
+		for _, rec := range voided {
+			rec.Voided = false
+		}
+
+		l.statements = l.statements[:count]
+		l.lastStored = lastStored
+
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Returns the id of the statement voided by stmt, empty when it isn't a voiding statement
+func voidedID(stmt statement.Statement) string {
+	if stmt.Verb.ID != statement.VerbVoided {
+		return ""
+	}
+
+	switch ref := stmt.Object.(type) {
+	case *statement.StatementRef:
+		return strings.ToLower(ref.ID)
+	case statement.StatementRef:
+		return strings.ToLower(ref.ID)
+	}
+
+	return ""
+}
+
+func (l *LRS) getStatements(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	for param := range q {
+		if !statementParams[param] {
+			httpError(w, http.StatusBadRequest, "unknown parameter "+param)
+			return
+		}
+	}
+
+	if q.Has("statementId") || q.Has("voidedStatementId") {
+		l.getStatement(w, q)
+		return
+	}
+
+	f, err := parseFilter(q)
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var matches []statement.Statement
+
+	for _, rec := range l.statements {
+		if !rec.Voided && f.match(rec.Statement) {
+			matches = append(matches, rec.Statement)
+		}
+	}
+
+	if !f.ascending {
+		for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+			matches[i], matches[j] = matches[j], matches[i]
+		}
+	}
+
+	limit := l.Options.PageSize
+
+	if f.limit > 0 && f.limit < limit {
+		limit = f.limit
+	}
+
+	result := statement.StatementResult{Statements: []statement.Statement{}}
+
+	if f.offset < len(matches) {
+		end := f.offset + limit
+
+		if end > len(matches) {
+			end = len(matches)
+		}
+
+		result.Statements = matches[f.offset:end]
+
+		// Later pages are bounded by the latest statement so statements stored meanwhile don't shift them
+		if end < len(matches) {
+			next := url.Values{}
+
+			for k, v := range q {
+				next[k] = v
+			}
+
+			next.Set("offset", strconv.Itoa(end))
+
+			if !next.Has("until") {
+				next.Set("until", statement.Timestamp{Time: l.lastStored}.String())
+			}
+
+			result.More = requestPath(r) + "?" + next.Encode()
+		}
+	}
+
+	w.Header().Set("X-Experience-API-Consistent-Through", statement.Timestamp{Time: time.Now().UTC()}.String())
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Serves a single statement, or a single voided statement
+func (l *LRS) getStatement(w http.ResponseWriter, q url.Values) {
+	for param := range q {
+		if param != "statementId" && param != "voidedStatementId" && param != "format" && param != "attachments" {
+			httpError(w, http.StatusBadRequest, param+" can't be used along with a statement id")
+			return
+		}
+	}
+
+	if q.Has("statementId") && q.Has("voidedStatementId") {
+		httpError(w, http.StatusBadRequest, "statementId and voidedStatementId can't be used together")
+		return
+	}
+
+	voided := q.Has("voidedStatementId")
+	id := q.Get("statementId")
+
+	if voided {
+		id = q.Get("voidedStatementId")
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	rec := l.byID[strings.ToLower(id)]
+
+	if rec == nil || rec.Voided != voided {
+		httpError(w, http.StatusNotFound, "statement not found")
+		return
+	}
+
+	w.Header().Set("Last-Modified", rec.Statement.Stored.UTC().Format(http.TimeFormat))
+	writeJSON(w, http.StatusOK, rec.Statement)
+}
+
+// Returns the path of the request as sent by the client, before any prefix was stripped
+func requestPath(r *http.Request) string {
+	if u, err := url.ParseRequestURI(r.RequestURI); err == nil {
+		return u.Path
+	}
+
+	return r.URL.Path
+}
+
+// Filters of a statement query
+type filter struct {
+	agent             string
+	verb              string
+	activity          string
+	registration      string
+	relatedActivities bool
+	relatedAgents     bool
+	since             *time.Time
+	until             *time.Time
+	limit             int
+	offset            int
+	ascending         bool
+}
+
+// Parses the filters of a statement query
+func parseFilter(q url.Values) (*filter, error) {
+	f := &filter{
+		verb:         q.Get("verb"),
+		activity:     q.Get("activity"),
+		registration: strings.ToLower(q.Get("registration")),
+	}
+
+	if q.Has("agent") {
+		var actor statement.IActor
+
+		if err := statement.UnmarshalActor(json.RawMessage(q.Get("agent")), &actor); err != nil {
+			return nil, statusError{http.StatusBadRequest, "invalid agent: " + err.Error()}
+		}
+
+		if f.agent = actorKey(actor); len(f.agent) == 0 {
+			return nil, statusError{http.StatusBadRequest, "agent must have an inverse functional identifier"}
+		}
+	}
+
+	bools := map[string]*bool{
+		"related_activities": &f.relatedActivities,
+		"related_agents":     &f.relatedAgents,
+		"ascending":          &f.ascending,
+	}
+
+	for param, value := range bools {
+		if !q.Has(param) {
+			continue
+		}
+
+		v, err := strconv.ParseBool(q.Get(param))
+
+		if err != nil {
+			return nil, statusError{http.StatusBadRequest, param + " must be a boolean"}
+		}
+
+		*value = v
+	}
+
+	if q.Has("attachments") {
+		if _, err := strconv.ParseBool(q.Get("attachments")); err != nil {
+			return nil, statusError{http.StatusBadRequest, "attachments must be a boolean"}
+		}
+	}
+
+	if format := q.Get("format"); len(format) > 0 && format != "exact" && format != "ids" && format != "canonical" {
+		return nil, statusError{http.StatusBadRequest, "format must be exact, ids or canonical"}
+	}
+
+	times := map[string]**time.Time{"since": &f.since, "until": &f.until}
+
+	for param, value := range times {
+		if !q.Has(param) {
+			continue
+		}
+
+		ts, err := statement.ParseTimestamp(q.Get(param))
+
+		if err != nil {
+			return nil, statusError{http.StatusBadRequest, "invalid " + param}
+		}
+
+		*value = &ts.Time
+	}
+
+	ints := map[string]*int{"limit": &f.limit, "offset": &f.offset}
+
+	for param, value := range ints {
+		if !q.Has(param) {
+			continue
+		}
+
+		v, err := strconv.Atoi(q.Get(param))
+
+		if err != nil || v < 0 {
+			return nil, statusError{http.StatusBadRequest, param + " must be a positive integer"}
+		}
+
+		*value = v
+	}
+
+	return f, nil
+}
+
+// Reports whether a statement matches the filters
+func (f *filter) match(stmt statement.Statement) bool {
+	if f.since != nil && !stmt.Stored.After(*f.since) {
+		return false
+	}
+
+	if f.until != nil && stmt.Stored.After(*f.until) {
+		return false
+	}
+
+	if len(f.verb) > 0 && stmt.Verb.ID != f.verb {
+		return false
+	}
+
+	if len(f.registration) > 0 && (stmt.Context == nil || stmt.Context.Registration == nil || strings.ToLower(*stmt.Context.Registration) != f.registration) {
+		return false
+	}
+
+	if len(f.agent) > 0 && !f.matchAgent(stmt) {
+		return false
+	}
+
+	if len(f.activity) > 0 && !f.matchActivity(stmt) {
+		return false
+	}
+
+	return true
+}
+
+// Reports whether the agent filter is the actor or the object, or with related agents anywhere in the statement
+func (f *filter) matchAgent(stmt statement.Statement) bool {
+	actors := []statement.IActor{stmt.Actor}
+
+	if actor, ok := stmt.Object.(statement.IActor); ok {
+		actors = append(actors, actor)
+	}
+
+	if f.relatedAgents {
+		actors = append(actors, stmt.Authority)
+		actors = append(actors, contextActors(stmt.Context)...)
+
+		if sub, ok := stmt.Object.(*statement.SubStatement); ok {
+			actors = append(actors, sub.Actor)
+
+			if actor, ok := sub.Object.(statement.IActor); ok {
+				actors = append(actors, actor)
+			}
+
+			actors = append(actors, contextActors(sub.Context)...)
+		}
+	}
+
+	for _, actor := range actors {
+		if actor != nil && actorKey(actor) == f.agent {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reports whether the activity filter is the object, or with related activities anywhere in the statement
+func (f *filter) matchActivity(stmt statement.Statement) bool {
+	activities := []statement.IObject{stmt.Object}
+
+	if f.relatedActivities {
+		activities = append(activities, contextActivities(stmt.Context)...)
+
+		if sub, ok := stmt.Object.(*statement.SubStatement); ok {
+			activities = append(activities, sub.Object)
+			activities = append(activities, contextActivities(sub.Context)...)
+		}
+	}
+
+	for _, object := range activities {
+		if activity, ok := object.(*statement.Activity); ok && activity.ID == f.activity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the instructor and team of a context
+func contextActors(ctx *statement.Context) []statement.IActor {
+	var actors []statement.IActor
+
+	if ctx != nil && ctx.Instructor != nil {
+		actors = append(actors, ctx.Instructor)
+	}
+
+	if ctx != nil && ctx.Team != nil {
+		actors = append(actors, ctx.Team)
+	}
+
+	return actors
+}
+
+// Returns the context activities of a context
+func contextActivities(ctx *statement.Context) []statement.IObject {
+	var objects []statement.IObject
+
+	if ctx == nil || ctx.ContextActivities == nil {
+		return objects
+	}
+
+	ca := ctx.ContextActivities
+
+	for _, list := range [][]statement.Activity{ca.Parent, ca.Grouping, ca.Category, ca.Other} {
+		for i := range list {
+			objects = append(objects, &list[i])
+		}
+	}
+
+	return objects
+}
+
+// Returns the key identifying an agent or an identified group, empty for anonymous groups
+func actorKey(actor statement.IActor) string {
+	switch a := actor.(type) {
+	case *statement.Agent:
+		return a.Key()
+	case *statement.Group:
+		return a.Key()
+	}
+
+	return ""
+}
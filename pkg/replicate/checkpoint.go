@@ -0,0 +1,91 @@
+package replicate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+)
+
+// Checkpoint records how far a replication has progressed so that it can be resumed
+type Checkpoint struct {
+	// Stored time of the last replicated statement
+	Since *time.Time `json:"since,omitempty"`
+	// Ids of the replicated statements which share the stored time above
+	IDs []string `json:"ids,omitempty"`
+}
+
+// LoadCheckpoint reads a checkpoint from the given file. A missing file yields an empty checkpoint.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	b, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return &Checkpoint{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	checkpoint := &Checkpoint{}
+
+	if err := json.Unmarshal(b, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint to the given file
+func (c *Checkpoint) Save(path string) error {
+	b, err := json.Marshal(c)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	if err := utils.WriteFileAtomic(path, b, 0600); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// Advance moves the checkpoint to the stored time of a replicated statement
+func (c *Checkpoint) Advance(id string, stored time.Time) {
+	if c.Since == nil || stored.After(*c.Since) {
+		c.Since = &stored
+		c.IDs = []string{id}
+		return
+	}
+
+	if stored.Equal(*c.Since) {
+		c.IDs = append(c.IDs, id)
+	}
+}
+
+// Contains reports whether a statement was already replicated according to the checkpoint
+func (c *Checkpoint) Contains(id string, stored time.Time) bool {
+	if c.Since == nil {
+		return false
+	}
+
+	if stored.Before(*c.Since) {
+		return true
+	}
+
+	if !stored.Equal(*c.Since) {
+		return false
+	}
+
+	for _, v := range c.IDs {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,432 @@
+package replicate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+)
+
+// Options of a replication
+type Options struct {
+	// File used to persist the checkpoint between runs. Replication always starts from the beginning if empty.
+	CheckpointFile string
+	// Number of statements requested per page
+	Limit *int64
+	// Only statements stored before this time are replicated
+	Until *time.Time
+	// Copies state, activity profile and agent profile documents related to the replicated statements
+	Documents bool
+	// Receives progress messages, discarded if nil
+	Logger *log.Logger
+}
+
+// Stats summarizes a replication
+type Stats struct {
+	Statements int
+	Voided     int
+	Conflicts  int
+	Skipped    int
+	Documents  int
+}
+
+// Replicator copies statements and documents from one LRS to another
+type Replicator struct {
+	Source  *client.RemoteLRS
+	Target  *client.RemoteLRS
+	Options Options
+	copied  map[string]bool
+}
+
+// NewReplicator creates a new replicator
+func NewReplicator(source *client.RemoteLRS, target *client.RemoteLRS, params ...*Options) *Replicator {
+	r := Replicator{
+		Source: source,
+		Target: target,
+		copied: make(map[string]bool),
+	}
+
+	if len(params) > 0 && params[0] != nil {
+		r.Options = *params[0]
+	}
+
+	if r.Options.Logger == nil {
+		r.Options.Logger = log.New(io.Discard, "", 0)
+	}
+
+	return &r
+}
+
+// Run replicates every statement stored on the source since the last checkpoint
+func (r *Replicator) Run() (*Stats, error) {
+	checkpoint := &Checkpoint{}
+
+	if len(r.Options.CheckpointFile) > 0 {
+		var err error
+
+		if checkpoint, err = LoadCheckpoint(r.Options.CheckpointFile); err != nil {
+			return nil, err
+		}
+	}
+
+	params := client.StatementQueryParams{
+		Ascending: utils.Ptr(true),
+		Limit:     r.Options.Limit,
+		Until:     r.Options.Until,
+	}
+
+	// since is exclusive, step back to pick up statements sharing the checkpoint's stored time
+	if checkpoint.Since != nil {
+		params.Since = utils.Ptr(checkpoint.Since.Add(-time.Millisecond))
+	}
+
+	stats := &Stats{}
+
+	result, resp, err := r.Source.QueryStatements(&params)
+
+	for {
+		if err != nil {
+			return stats, fmt.Errorf("failed to query source: %w", err)
+		}
+
+		if resp.Status != 200 {
+			return stats, fmt.Errorf("failed to query source: %w", resp.Err())
+		}
+
+		for _, stmt := range result.Statements {
+			if err := r.replicate(stmt, checkpoint, stats); err != nil {
+				return stats, err
+			}
+		}
+
+		if len(r.Options.CheckpointFile) > 0 {
+			if err := checkpoint.Save(r.Options.CheckpointFile); err != nil {
+				return stats, err
+			}
+		}
+
+		if len(result.More) == 0 {
+			break
+		}
+
+		result, resp, err = r.Source.MoreStatements(result.More)
+	}
+
+	return stats, nil
+}
+
+func (r *Replicator) replicate(stmt statement.Statement, checkpoint *Checkpoint, stats *Stats) error {
+	if stmt.ID == nil {
+		return errors.New("source returned a statement without an id")
+	}
+
+	if stmt.Stored != nil && checkpoint.Contains(*stmt.ID, stmt.Stored.Time) {
+		stats.Skipped++
+		return nil
+	}
+
+	// The source hides voided statements from queries, so the target would never receive them.
+	// Push the voided statement right before the voiding one to keep both stores consistent.
+	if stmt.Verb.ID == statement.VerbVoided {
+		if ref := statementRef(stmt.Object); ref != nil {
+			voided, resp, err := r.Source.GetVoidedStatement(ref.ID)
+
+			if err != nil {
+				return fmt.Errorf("failed to fetch voided statement %s: %w", ref.ID, err)
+			}
+
+			if resp.Status == 200 {
+				if err := r.push(*voided, stats); err != nil {
+					return err
+				}
+
+				stats.Voided++
+			}
+		}
+	}
+
+	if err := r.push(stmt, stats); err != nil {
+		return err
+	}
+
+	stats.Statements++
+
+	if stmt.Stored != nil {
+		checkpoint.Advance(*stmt.ID, stmt.Stored.Time)
+	}
+
+	if r.Options.Documents {
+		if err := r.copyDocuments(stmt, stats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Replicator) push(stmt statement.Statement, stats *Stats) error {
+	_, resp, err := r.Target.SaveStatement(stmt)
+
+	if err != nil {
+		return fmt.Errorf("failed to save statement %s: %w", *stmt.ID, err)
+	}
+
+	switch resp.Status {
+	case 200, 204:
+		r.Options.Logger.Printf("replicated statement %s", *stmt.ID)
+	case 409:
+		// Some LRSs reject any statement whose id they already have, even when it is equivalent
+		existing, _, err := r.Target.GetStatement(*stmt.ID)
+
+		if err != nil {
+			return fmt.Errorf("failed to fetch conflicting statement %s: %w", *stmt.ID, err)
+		}
+
+		if existing != nil {
+			if equal, _ := statement.Equivalent(stmt, *existing); equal {
+				r.Options.Logger.Printf("statement %s already exists on target", *stmt.ID)
+				return nil
+			}
+		}
+
+		stats.Conflicts++
+		r.Options.Logger.Printf("statement %s conflicts with an existing statement on target", *stmt.ID)
+	default:
+		return fmt.Errorf("failed to save statement %s: %w", *stmt.ID, resp.Err())
+	}
+
+	return nil
+}
+
+func (r *Replicator) copyDocuments(stmt statement.Statement, stats *Stats) error {
+	agent := agentOf(stmt.Actor)
+	activity := activityOf(stmt.Object)
+
+	if agent != nil {
+		if err := r.copyAgentProfiles(*agent, stats); err != nil {
+			return err
+		}
+	}
+
+	if activity != nil {
+		if err := r.copyActivityProfiles(*activity, stats); err != nil {
+			return err
+		}
+	}
+
+	if agent != nil && activity != nil {
+		var registration *string
+
+		if stmt.Context != nil {
+			registration = stmt.Context.Registration
+		}
+
+		if err := r.copyStates(*activity, *agent, registration, stats); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Replicator) copyStates(activity statement.Activity, agent statement.Agent, registration *string, stats *Stats) error {
+	key := "state|" + activity.ID + "|" + agent.ToJSON()
+
+	if registration != nil {
+		key += "|" + *registration
+	}
+
+	if r.copied[key] {
+		return nil
+	}
+
+	ids, resp, err := r.Source.GetStateIds(activity, agent, &client.GetStateIdsOptionalParams{Registration: registration})
+
+	if err != nil {
+		return fmt.Errorf("failed to list states: %w", err)
+	}
+
+	if resp.Status != 200 {
+		return fmt.Errorf("failed to list states: %w", resp.Err())
+	}
+
+	for _, id := range ids {
+		doc, resp, err := r.Source.GetState(activity, agent, id, &client.GetStateOptionalParams{Registration: registration})
+
+		if err != nil {
+			return fmt.Errorf("failed to fetch state %s: %w", id, err)
+		}
+
+		if resp.Status != 200 {
+			return fmt.Errorf("failed to fetch state %s: %w", id, resp.Err())
+		}
+
+		// States are overwritten without preconditions
+		doc.Etag = ""
+
+		if _, resp, err = r.Target.SaveState(doc); err != nil {
+			return fmt.Errorf("failed to save state %s: %w", id, err)
+		}
+
+		if resp.Status != 204 {
+			return fmt.Errorf("failed to save state %s: %w", id, resp.Err())
+		}
+
+		stats.Documents++
+	}
+
+	r.copied[key] = true
+
+	return nil
+}
+
+func (r *Replicator) copyActivityProfiles(activity statement.Activity, stats *Stats) error {
+	key := "activity|" + activity.ID
+
+	if r.copied[key] {
+		return nil
+	}
+
+	ids, resp, err := r.Source.GetActivityProfileIds(activity)
+
+	if err != nil {
+		return fmt.Errorf("failed to list activity profiles: %w", err)
+	}
+
+	if resp.Status != 200 {
+		return fmt.Errorf("failed to list activity profiles: %w", resp.Err())
+	}
+
+	for _, id := range ids {
+		doc, resp, err := r.Source.GetActivityProfile(activity, id)
+
+		if err != nil {
+			return fmt.Errorf("failed to fetch activity profile %s: %w", id, err)
+		}
+
+		if resp.Status != 200 {
+			return fmt.Errorf("failed to fetch activity profile %s: %w", id, resp.Err())
+		}
+
+		// Profiles can only be overwritten by matching the target's etag
+		existing, _, err := r.Target.GetActivityProfile(activity, id)
+
+		if err != nil {
+			return fmt.Errorf("failed to fetch activity profile %s from target: %w", id, err)
+		}
+
+		doc.Etag = ""
+
+		if existing != nil {
+			doc.Etag = existing.Etag
+		}
+
+		if _, resp, err = r.Target.SaveActivityProfile(doc); err != nil {
+			return fmt.Errorf("failed to save activity profile %s: %w", id, err)
+		}
+
+		if resp.Status != 204 {
+			return fmt.Errorf("failed to save activity profile %s: %w", id, resp.Err())
+		}
+
+		stats.Documents++
+	}
+
+	r.copied[key] = true
+
+	return nil
+}
+
+func (r *Replicator) copyAgentProfiles(agent statement.Agent, stats *Stats) error {
+	key := "agent|" + agent.ToJSON()
+
+	if r.copied[key] {
+		return nil
+	}
+
+	ids, resp, err := r.Source.GetAgentProfileIds(agent)
+
+	if err != nil {
+		return fmt.Errorf("failed to list agent profiles: %w", err)
+	}
+
+	if resp.Status != 200 {
+		return fmt.Errorf("failed to list agent profiles: %w", resp.Err())
+	}
+
+	for _, id := range ids {
+		doc, resp, err := r.Source.GetAgentProfile(agent, id)
+
+		if err != nil {
+			return fmt.Errorf("failed to fetch agent profile %s: %w", id, err)
+		}
+
+		if resp.Status != 200 {
+			return fmt.Errorf("failed to fetch agent profile %s: %w", id, resp.Err())
+		}
+
+		existing, _, err := r.Target.GetAgentProfile(agent, id)
+
+		if err != nil {
+			return fmt.Errorf("failed to fetch agent profile %s from target: %w", id, err)
+		}
+
+		doc.Etag = ""
+
+		if existing != nil {
+			doc.Etag = existing.Etag
+		}
+
+		if _, resp, err = r.Target.SaveAgentProfile(doc); err != nil {
+			return fmt.Errorf("failed to save agent profile %s: %w", id, err)
+		}
+
+		if resp.Status != 204 {
+			return fmt.Errorf("failed to save agent profile %s: %w", id, resp.Err())
+		}
+
+		stats.Documents++
+	}
+
+	r.copied[key] = true
+
+	return nil
+}
+
+func statementRef(object statement.IObject) *statement.StatementRef {
+	switch v := object.(type) {
+	case *statement.StatementRef:
+		return v
+	case statement.StatementRef:
+		return &v
+	}
+
+	return nil
+}
+
+func agentOf(actor statement.IActor) *statement.Agent {
+	switch v := actor.(type) {
+	case *statement.Agent:
+		return v
+	case statement.Agent:
+		return &v
+	}
+
+	return nil
+}
+
+func activityOf(object statement.IObject) *statement.Activity {
+	switch v := object.(type) {
+	case *statement.Activity:
+		return v
+	case statement.Activity:
+		return &v
+	}
+
+	return nil
+}
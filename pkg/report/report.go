@@ -0,0 +1,293 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+)
+
+// Verbs meaning the activity was completed, whatever the completion of the result
+var completedVerbs = map[string]bool{
+	"http://adlnet.gov/expapi/verbs/completed": true,
+	"http://adlnet.gov/expapi/verbs/passed":    true,
+	"http://adlnet.gov/expapi/verbs/mastered":  true,
+}
+
+// Verbs meaning the activity was passed or failed, when the result has no success
+var successVerbs = map[string]bool{
+	"http://adlnet.gov/expapi/verbs/passed": true,
+	"http://adlnet.gov/expapi/verbs/failed": false,
+}
+
+// Grouping of the statements summarized together
+type Grouping int
+
+const (
+	// A summary per agent and activity
+	ByAgentActivity Grouping = iota
+	// A summary per activity, across agents
+	ByActivity
+)
+
+// Summary aggregates the statements about an activity, of a single agent or of every agent
+type Summary struct {
+	// IFI of the agent, empty when grouped by activity
+	Agent        string `json:"agent,omitempty"`
+	AgentName    string `json:"agent_name,omitempty"`
+	Activity     string `json:"activity"`
+	ActivityName string `json:"activity_name,omitempty"`
+	// Number of distinct agents
+	Learners   int `json:"learners"`
+	Statements int `json:"statements"`
+	// Whether any statement completed the activity, with the completed, passed or mastered verbs or a completion
+	Completed       bool                 `json:"completed"`
+	Completions     int                  `json:"completions"`
+	FirstCompletion *statement.Timestamp `json:"first_completion,omitempty"`
+	// Statements with a success, or the passed and failed verbs
+	Passed   int      `json:"passed"`
+	Failed   int      `json:"failed"`
+	PassRate *float64 `json:"pass_rate,omitempty"`
+	// Statements with a scaled score, or a raw score within bounds
+	Scores       int      `json:"scores"`
+	BestScore    *float64 `json:"best_score,omitempty"`
+	AverageScore *float64 `json:"average_score,omitempty"`
+	// Sum of the result durations, approximating nominal units such as months
+	Duration     *statement.Duration  `json:"duration,omitempty"`
+	FirstAttempt *statement.Timestamp `json:"first_attempt,omitempty"`
+	LastAttempt  *statement.Timestamp `json:"last_attempt,omitempty"`
+
+	scoreTotal float64
+	duration   time.Duration
+	learners   map[string]bool
+}
+
+// Aggregator adds statements up into summaries
+type Aggregator struct {
+	grouping  Grouping
+	summaries map[string]*Summary
+}
+
+// NewAggregator creates an aggregator summarizing statements with the given grouping
+func NewAggregator(grouping Grouping) *Aggregator {
+	return &Aggregator{grouping: grouping, summaries: make(map[string]*Summary)}
+}
+
+// Add adds a statement to its summary. Statements about anything but an activity, or by an agent or group without
+// an IFI, are ignored.
+func (a *Aggregator) Add(stmt statement.Statement) {
+	activity, ok := stmt.Object.(*statement.Activity)
+
+	if !ok {
+		return
+	}
+
+	agent, name := actorKey(stmt.Actor)
+
+	if len(agent) == 0 {
+		return
+	}
+
+	key := activity.ID
+
+	if a.grouping == ByAgentActivity {
+		key = agent + "\x00" + key
+	}
+
+	s := a.summaries[key]
+
+	if s == nil {
+		s = &Summary{Activity: activity.ID, learners: make(map[string]bool)}
+
+		if a.grouping == ByAgentActivity {
+			s.Agent = agent
+		}
+
+		a.summaries[key] = s
+	}
+
+	if len(name) > 0 && a.grouping == ByAgentActivity {
+		s.AgentName = name
+	}
+
+	if activity.Definition != nil && activity.Definition.Name != nil {
+		if name := activity.Definition.Name.Best(); len(name) > 0 {
+			s.ActivityName = name
+		}
+	}
+
+	s.learners[agent] = true
+	s.Statements++
+
+	at := stmt.Timestamp
+
+	if at == nil {
+		at = stmt.Stored
+	}
+
+	if at != nil {
+		if s.FirstAttempt == nil || at.Before(s.FirstAttempt.Time) {
+			s.FirstAttempt = at
+		}
+
+		if s.LastAttempt == nil || at.After(s.LastAttempt.Time) {
+			s.LastAttempt = at
+		}
+	}
+
+	result := stmt.Result
+
+	if completedVerbs[stmt.Verb.ID] || (result != nil && result.Completion != nil && *result.Completion) {
+		s.Completed = true
+		s.Completions++
+
+		if at != nil && (s.FirstCompletion == nil || at.Before(s.FirstCompletion.Time)) {
+			s.FirstCompletion = at
+		}
+	}
+
+	success, ok := successVerbs[stmt.Verb.ID]
+
+	if result != nil && result.Success != nil {
+		success, ok = *result.Success, true
+	}
+
+	if ok && success {
+		s.Passed++
+	} else if ok {
+		s.Failed++
+	}
+
+	if result == nil {
+		return
+	}
+
+	if scaled, ok := scaledScore(result.Score); ok {
+		s.Scores++
+		s.scoreTotal += scaled
+
+		if s.BestScore == nil || scaled > *s.BestScore {
+			s.BestScore = &scaled
+		}
+	}
+
+	if result.Duration != nil {
+		s.duration += result.Duration.ToTimeDuration()
+	}
+}
+
+// Summaries returns the summaries ordered by activity, then agent
+func (a *Aggregator) Summaries() []Summary {
+	summaries := make([]Summary, 0, len(a.summaries))
+
+	for _, s := range a.summaries {
+		summary := *s
+		summary.Learners = len(s.learners)
+
+		if summary.Passed+summary.Failed > 0 {
+			summary.PassRate = round(float64(summary.Passed) / float64(summary.Passed+summary.Failed))
+		}
+
+		if summary.Scores > 0 {
+			summary.AverageScore = round(s.scoreTotal / float64(summary.Scores))
+			summary.BestScore = round(*s.BestScore)
+		}
+
+		if s.duration > 0 {
+			summary.Duration = statement.NewDuration(s.duration)
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Activity != summaries[j].Activity {
+			return summaries[i].Activity < summaries[j].Activity
+		}
+
+		return summaries[i].Agent < summaries[j].Agent
+	})
+
+	return summaries
+}
+
+// Query adds the statements matching a query, following more links until every page is fetched, and returns
+// the number of statements fetched
+func (a *Aggregator) Query(lrs *client.RemoteLRS, query *client.StatementQueryParams) (int, error) {
+	count := 0
+	result, resp, err := lrs.QueryStatements(query)
+
+	for {
+		if err != nil {
+			return count, fmt.Errorf("failed to query statements: %w", err)
+		}
+
+		if result == nil || resp.Status != 200 {
+			return count, fmt.Errorf("failed to query statements: %w", resp.Err())
+		}
+
+		for _, stmt := range result.Statements {
+			a.Add(stmt)
+		}
+
+		count += len(result.Statements)
+
+		if len(result.More) == 0 {
+			return count, nil
+		}
+
+		result, resp, err = lrs.MoreStatements(result.More)
+	}
+}
+
+// Returns the key of the actor, its IFI, along with its name
+func actorKey(actor statement.IActor) (string, string) {
+	var ifi statement.IFI
+	var name *string
+	var err error
+
+	switch a := actor.(type) {
+	case *statement.Agent:
+		ifi, err = a.IFI()
+		name = a.Name
+	case *statement.Group:
+		ifi, err = a.IFI()
+		name = a.Name
+	default:
+		return "", ""
+	}
+
+	if err != nil {
+		return "", ""
+	}
+
+	if name == nil {
+		return ifi.String(), ""
+	}
+
+	return ifi.String(), *name
+}
+
+// Returns the scaled score, derived from the raw score when only it and its bounds are given. Raw scores out of
+// their bounds are ignored.
+func scaledScore(score *statement.Score) (float64, bool) {
+	switch {
+	case score == nil:
+		return 0, false
+	case score.Scaled != nil:
+		return *score.Scaled, true
+	case score.Raw != nil && score.Min != nil && score.Max != nil && *score.Max > *score.Min && *score.Raw >= *score.Min && *score.Raw <= *score.Max:
+		return (*score.Raw - *score.Min) / (*score.Max - *score.Min), true
+	}
+
+	return 0, false
+}
+
+// Rounds a ratio to 3 decimals, which is as precise as reports need
+func round(v float64) *float64 {
+	rounded := math.Round(v*1000) / 1000
+	return &rounded
+}
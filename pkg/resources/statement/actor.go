@@ -20,8 +20,9 @@ func UnmarshalActor(m json.RawMessage, actor *IActor) error {
 		return err
 	}
 
+	// The object type of an actor defaults to Agent
 	switch obj.ObjectType {
-	case "Agent":
+	case "Agent", "":
 		*actor = new(Agent)
 	case "Group":
 		*actor = new(Group)
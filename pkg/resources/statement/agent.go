@@ -5,11 +5,11 @@ import "encoding/json"
 // The Actor defines who performed the action. The Actor of a Statement can be an Agent or a Group.
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#actor
 type Agent struct {
-	ObjectType  string   `json:"objectType" xapi:"required"`
+	ObjectType  string   `json:"objectType,omitempty" xapi:"optional"`
 	Name        *string  `json:"name,omitempty" xapi:"optional"`
 	Mbox        *string  `json:"mbox,omitempty" xapi:"optional"`
 	MboxSHA1Sum *string  `json:"mbox_sha1sum,omitempty" xapi:"optional"`
-	OpenID      *string  `json:"open_id,omitempty" xapi:"optional"`
+	OpenID      *string  `json:"openid,omitempty" xapi:"optional"`
 	Account     *Account `json:"account,omitempty" xapi:"optional"`
 }
 
@@ -30,8 +30,10 @@ func (a *Agent) ToJSON() string {
 	return string(b)
 }
 
-// Creates a new agent with a mailbox
+// Creates a new agent with a mailbox. The mailto: scheme is added when missing.
 func NewAgentWithMbox(name string, mbox string) *Agent {
+	mbox = prefixMbox(mbox)
+
 	return &Agent{
 		ObjectType: "Agent",
 		Name:       &name,
@@ -66,8 +68,10 @@ func NewAgentWithAccount(name string, account *Account) *Agent {
 	}
 }
 
-// Creates a new anonymous agent with a mailbox
+// Creates a new anonymous agent with a mailbox. The mailto: scheme is added when missing.
 func NewAnonymousAgentWithMbox(mbox string) *Agent {
+	mbox = prefixMbox(mbox)
+
 	return &Agent{
 		ObjectType: "Agent",
 		Mbox:       &mbox,
@@ -1,5 +1,13 @@
 package statement
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Usage type of the attachment carrying a statement signature
+const AttachmentUsageSignature = "http://adlnet.gov/expapi/attachments/signature"
+
 // In some cases an Attachment is logically an important part of a Learning Record.
 // It could be an essay, a video, etc. Another example of such an Attachment is (the image of)
 // a certificate that was granted as a result of an experience. It is useful to have a way to
@@ -11,8 +19,10 @@ type Attachment struct {
 	Display     LanguageMap `json:"display" xapi:"required"`
 	ContentType string      `json:"contentType" xapi:"required"`
 	Length      int64       `json:"length" xapi:"required"`
-	SHA2        string      `json:"sha1" xapi:"required"`
+	SHA2        string      `json:"sha2" xapi:"required"`
 	AttachmentOptions
+	// Raw attachment data. It is transmitted as a multipart/mixed part instead of being serialized.
+	Content []byte `json:"-"`
 }
 
 // Attachment optional paramets
@@ -42,10 +52,20 @@ func NewAttachment(usageType string, display LanguageMap, contentType string, le
 			attachment.Description = opt.Description
 		}
 
-		if opt.Description != nil {
+		if opt.FileUrl != nil {
 			attachment.FileUrl = opt.FileUrl
 		}
 	}
 
 	return &attachment
 }
+
+// Creates a new attachment from its raw data, computing its length and hash
+func NewAttachmentWithContent(usageType string, display LanguageMap, contentType string, content []byte, params ...*AttachmentOptions) *Attachment {
+	sum := sha256.Sum256(content)
+
+	attachment := NewAttachment(usageType, display, contentType, int64(len(content)), hex.EncodeToString(sum[:]), params...)
+	attachment.Content = content
+
+	return attachment
+}
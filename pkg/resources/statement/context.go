@@ -1,10 +1,12 @@
 package statement
 
+import "encoding/json"
+
 // An optional property that provides a place to add contextual information to a Statement. All "context" properties are optional.
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#246-context
 type Context struct {
 	Registration      *string            `json:"registration,omitempty" xapi:"optional"`
-	Instructor        IActor             `json:"agent,omitempty" xapi:"optional"`
+	Instructor        IActor             `json:"instructor,omitempty" xapi:"optional"`
 	Team              *Group             `json:"team,omitempty" xapi:"optional"`
 	ContextActivities *ContextActivities `json:"contextActivities,omitempty" xapi:"optional"`
 	Revision          *string            `json:"revision,omitempty" xapi:"optional"`
@@ -13,3 +15,42 @@ type Context struct {
 	Statement         *StatementRef      `json:"statement,omitempty" xapi:"optional"`
 	Extensions        *Extensions        `json:"extensions,omitempty" xapi:"optional"`
 }
+
+// Unmarshals the context. A custom unmarshaller is required due to Instructor being an interface.
+func (c *Context) UnmarshalJSON(data []byte) error {
+	type context Context
+
+	raw := struct {
+		Instructor json.RawMessage `json:"instructor,omitempty"`
+		*context
+	}{context: (*context)(c)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.Instructor = nil
+
+	if len(raw.Instructor) > 0 && string(raw.Instructor) != "null" {
+		if err := UnmarshalActor(raw.Instructor, &c.Instructor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the language tag and the extensions against the given registry, or the default registry
+func (c *Context) Validate(registry ...*ExtensionRegistry) error {
+	var errs ValidationErrors
+
+	if c.Language != nil {
+		if !validLanguageTag(*c.Language) {
+			errs = append(errs, ValidationError{Path: "language", Message: "invalid language tag"})
+		}
+	}
+
+	errs = append(errs, extensionRegistry(registry).validate(c.Extensions, "extensions")...)
+
+	return errs.err()
+}
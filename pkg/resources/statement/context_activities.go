@@ -1,5 +1,10 @@
 package statement
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 // A map of the types of learning activity context that this Statement is related to.
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#246-context
 type ContextActivities struct {
@@ -31,3 +36,44 @@ func (c *ContextActivities) Append(ctxType string, obj Activity) {
 func NewContextActivityList() *ContextActivities {
 	return &ContextActivities{}
 }
+
+// Unmarshals the context activities. Each property can be a single activity or an array of activities.
+func (c *ContextActivities) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	lists := map[string]*[]Activity{
+		"parent":   &c.Parent,
+		"grouping": &c.Grouping,
+		"category": &c.Category,
+		"other":    &c.Other,
+	}
+
+	for key, list := range lists {
+		value := bytes.TrimSpace(raw[key])
+
+		if len(value) == 0 || string(value) == "null" {
+			continue
+		}
+
+		if value[0] == '{' {
+			var activity Activity
+
+			if err := json.Unmarshal(value, &activity); err != nil {
+				return err
+			}
+
+			*list = []Activity{activity}
+			continue
+		}
+
+		if err := json.Unmarshal(value, list); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,24 @@ package statement
 
 import "errors"
 
+// Interaction types
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#interaction-activities
+const (
+	InteractionTrueFalse   = "true-false"
+	InteractionChoice      = "choice"
+	InteractionFillIn      = "fill-in"
+	InteractionLongFillIn  = "long-fill-in"
+	InteractionMatching    = "matching"
+	InteractionPerformance = "performance"
+	InteractionSequencing  = "sequencing"
+	InteractionLikert      = "likert"
+	InteractionNumeric     = "numeric"
+	InteractionOther       = "other"
+)
+
+// Activity type of interaction activities
+const ActivityTypeCMIInteraction = "http://adlnet.gov/expapi/activities/cmi.interaction"
+
 // Object metadata
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#activity-definition
 type ActivityDefinition struct {
@@ -21,7 +39,7 @@ type ActivityDefinition struct {
 
 // Adds a choice interaction component to the activity definition
 func (ad *ActivityDefinition) AddChoice(component InteractionComponent) error {
-	if *ad.InteractionType != "choice" && *ad.InteractionType != "sequencing" {
+	if !ad.isInteraction(InteractionChoice, InteractionSequencing) {
 		return errors.New("interaction type must be choice or sequencing")
 	}
 
@@ -31,7 +49,7 @@ func (ad *ActivityDefinition) AddChoice(component InteractionComponent) error {
 
 // Adds a scale interaction component to the activity definition
 func (ad *ActivityDefinition) AddScale(component InteractionComponent) error {
-	if *ad.InteractionType != "likert" {
+	if !ad.isInteraction(InteractionLikert) {
 		return errors.New("interaction type must be likert")
 	}
 
@@ -41,7 +59,7 @@ func (ad *ActivityDefinition) AddScale(component InteractionComponent) error {
 
 // Adds a matching interaction component to the activity definition
 func (ad *ActivityDefinition) AddMatching(component InteractionComponent, isTarget bool) error {
-	if *ad.InteractionType != "matching" {
+	if !ad.isInteraction(InteractionMatching) {
 		return errors.New("interaction type must be matching")
 	}
 
@@ -56,10 +74,46 @@ func (ad *ActivityDefinition) AddMatching(component InteractionComponent, isTarg
 
 // Adds a steps interaction component to the activity definition
 func (ad *ActivityDefinition) AddSteps(component InteractionComponent) error {
-	if *ad.InteractionType != "performance" {
+	if !ad.isInteraction(InteractionPerformance) {
 		return errors.New("interaction type must be performance")
 	}
 
 	ad.Steps = append(ad.Steps, component)
 	return nil
 }
+
+// Validate checks the language maps and the extensions against the given registry, or the default registry
+func (ad *ActivityDefinition) Validate(registry ...*ExtensionRegistry) error {
+	var errs ValidationErrors
+
+	if ad.Name != nil {
+		if err := ad.Name.Validate(); err != nil {
+			errs = append(errs, ValidationError{Path: "name", Message: err.Error()})
+		}
+	}
+
+	if ad.Description != nil {
+		if err := ad.Description.Validate(); err != nil {
+			errs = append(errs, ValidationError{Path: "description", Message: err.Error()})
+		}
+	}
+
+	errs = append(errs, extensionRegistry(registry).validate(ad.Extensions, "extensions")...)
+
+	return errs.err()
+}
+
+// Checks whether the interaction type is one of the given types
+func (ad *ActivityDefinition) isInteraction(types ...string) bool {
+	if ad.InteractionType == nil {
+		return false
+	}
+
+	for _, t := range types {
+		if *ad.InteractionType == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,212 @@
+package statement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is an ISO 8601 duration, such as PT1H2M3.45S, as used by Result.Duration.
+// It keeps the components it was parsed from, so that it is formatted back as it was written, unless it was more
+// precise than the 0.01 second required by the spec.
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#245-result
+type Duration struct {
+	// Years, months, weeks, days, hours, minutes and seconds, as written, empty when omitted
+	parts    [7]string
+	negative bool
+}
+
+var durationPattern = regexp.MustCompile(`^P(?:(\d+(?:[.,]\d+)?)Y)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+var durationDesignators = []string{"Y", "M", "W", "D", "H", "M", "S"}
+
+// Lengths the components are approximated with, nominal units having no fixed length
+var durationUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+// NewDuration creates a duration of hours, minutes and seconds from a time.Duration, truncated to the 0.01 second
+// precision of the spec
+func NewDuration(d time.Duration) *Duration {
+	d = d.Truncate(10 * time.Millisecond)
+	duration := &Duration{negative: d < 0}
+
+	if d < 0 {
+		d = -d
+	}
+
+	if h := d / time.Hour; h > 0 {
+		duration.parts[4] = strconv.FormatInt(int64(h), 10)
+		d -= h * time.Hour
+	}
+
+	if m := d / time.Minute; m > 0 {
+		duration.parts[5] = strconv.FormatInt(int64(m), 10)
+		d -= m * time.Minute
+	}
+
+	if d > 0 {
+		duration.parts[6] = strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+	}
+
+	return duration
+}
+
+// ParseDuration parses an ISO 8601 duration
+func ParseDuration(s string) (Duration, error) {
+	m := durationPattern.FindStringSubmatch(s)
+
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return Duration{}, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var d Duration
+
+	copy(d.parts[:], m[1:])
+
+	smallest := len(d.parts) - 1
+
+	for len(d.parts[smallest]) == 0 {
+		smallest--
+	}
+
+	for _, part := range d.parts[:smallest] {
+		if strings.ContainsAny(part, ".,") {
+			return Duration{}, fmt.Errorf("invalid duration %q, only its smallest component can have a fraction", s)
+		}
+	}
+
+	if d.approximate() > math.MaxInt64 {
+		return Duration{}, fmt.Errorf("duration %q overflows", s)
+	}
+
+	d.truncate(smallest)
+
+	return d, nil
+}
+
+// Truncates the fraction of the smallest component, at index i, to 0.01 second. A fraction of hours or minutes
+// which is more precise is written as smaller components instead. Fractions of days and longer are kept as
+// their length is nominal.
+func (d *Duration) truncate(i int) {
+	part := d.parts[i]
+	sep := strings.IndexAny(part, ".,")
+
+	if sep < 0 || i < 4 {
+		return
+	}
+
+	if i == 6 {
+		if len(part) > sep+3 {
+			d.parts[6] = strings.TrimRight(strings.TrimRight(part[:sep+3], "0"), ".,")
+		}
+
+		return
+	}
+
+	fraction, _ := strconv.ParseFloat("0."+part[sep+1:], 64)
+	rest := time.Duration(math.Round(fraction * float64(durationUnits[i])))
+
+	if rest%(10*time.Millisecond) == 0 {
+		return
+	}
+
+	smaller := NewDuration(rest)
+
+	d.parts[i] = part[:sep]
+	copy(d.parts[i+1:], smaller.parts[i+1:])
+}
+
+// ToTimeDuration converts the duration to a time.Duration. The conversion is lossy: nominal units are approximated as
+// 1Y = 365D, 1M = 30D, 1W = 7D and 1D = 24H, and formatting the result doesn't give back the original duration.
+func (d Duration) ToTimeDuration() time.Duration {
+	v := time.Duration(math.Round(d.approximate()))
+
+	if d.negative {
+		return -v
+	}
+
+	return v
+}
+
+// Returns the length of the duration in nanoseconds
+func (d Duration) approximate() float64 {
+	var total float64
+
+	for i, unit := range durationUnits {
+		if len(d.parts[i]) == 0 {
+			continue
+		}
+
+		v, _ := strconv.ParseFloat(strings.Replace(d.parts[i], ",", ".", 1), 64)
+		total += v * float64(unit)
+	}
+
+	return total
+}
+
+// String formats the duration in ISO 8601, with the components it was parsed from
+func (d Duration) String() string {
+	if d.parts == [7]string{} {
+		return "PT0S"
+	}
+
+	var sb strings.Builder
+
+	if d.negative {
+		sb.WriteString("-")
+	}
+
+	sb.WriteString("P")
+
+	for i, part := range d.parts {
+		if i == 4 && len(d.parts[4])+len(d.parts[5])+len(d.parts[6]) > 0 {
+			sb.WriteString("T")
+		}
+
+		if len(part) > 0 {
+			sb.WriteString(part + durationDesignators[i])
+		}
+	}
+
+	return sb.String()
+}
+
+// MarshalJSON marshals the duration as an ISO 8601 string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if d.negative {
+		return nil, errors.New("duration can't be negative")
+	}
+
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON unmarshals an ISO 8601 duration, rejecting malformed values
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	v, err := ParseDuration(s)
+
+	if err != nil {
+		return err
+	}
+
+	*d = v
+
+	return nil
+}
@@ -0,0 +1,303 @@
+package statement
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Difference describes a property which differs between two statements
+type Difference struct {
+	// Dotted path of the property, e.g. object.definition.name.en-us
+	Path string
+	A    any
+	B    any
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %v != %v", d.Path, d.A, d.B)
+}
+
+// Equivalent reports whether two statements are equivalent according to the spec's statement comparison requirements.
+// Differences that could have been caused by an LRS processing the statement are ignored:
+//   - stored, authority and version
+//   - case of uuids, sha sums and language tags
+//   - precision and time zone of the timestamp, down to milliseconds
+//   - precision of the result duration, down to 0.01 second, and its decimal separator
+//   - order of group members and attachments
+//   - an omitted objectType where it has a default
+//   - a single context activity instead of an array
+//
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#statement-comparision-requirements
+func Equivalent(a Statement, b Statement) (bool, []Difference) {
+	na, err := normalizedStatement(a)
+
+	if err != nil {
+		return false, []Difference{{Path: "", A: err, B: nil}}
+	}
+
+	nb, err := normalizedStatement(b)
+
+	if err != nil {
+		return false, []Difference{{Path: "", A: nil, B: err}}
+	}
+
+	var diffs []Difference
+	compare("", na, nb, &diffs)
+
+	return len(diffs) == 0, diffs
+}
+
+func normalizedStatement(s Statement) (map[string]any, error) {
+	b, err := json.Marshal(s)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal statement: %w", err)
+	}
+
+	m := make(map[string]any)
+
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal statement: %w", err)
+	}
+
+	delete(m, "stored")
+	delete(m, "authority")
+	delete(m, "version")
+
+	normalizeStatement(m)
+
+	return m, nil
+}
+
+func normalizeStatement(m map[string]any) {
+	lowerString(m, "id")
+
+	if ts, ok := m["timestamp"].(string); ok {
+		if t, err := ParseTimestamp(ts); err == nil {
+			m["timestamp"] = t.UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano)
+		}
+	}
+
+	if result, ok := m["result"].(map[string]any); ok {
+		if s, ok := result["duration"].(string); ok {
+			// Durations are already truncated to 0.01 second when parsed
+			result["duration"] = strings.Replace(s, ",", ".", 1)
+		}
+	}
+
+	normalizeActor(m["actor"])
+
+	if verb, ok := m["verb"].(map[string]any); ok {
+		normalizeLanguageMap(verb["display"])
+	}
+
+	normalizeObject(m["object"])
+
+	if ctx, ok := m["context"].(map[string]any); ok {
+		lowerString(ctx, "registration")
+		normalizeActor(ctx["instructor"])
+		normalizeActor(ctx["team"])
+
+		if ref, ok := ctx["statement"].(map[string]any); ok {
+			lowerString(ref, "id")
+		}
+
+		if ca, ok := ctx["contextActivities"].(map[string]any); ok {
+			for k, v := range ca {
+				// A single activity is equivalent to an array holding it
+				if single, ok := v.(map[string]any); ok {
+					v = []any{single}
+					ca[k] = v
+				}
+
+				if list, ok := v.([]any); ok {
+					for _, activity := range list {
+						normalizeObject(activity)
+					}
+				}
+			}
+		}
+	}
+
+	if attachments, ok := m["attachments"].([]any); ok {
+		for _, v := range attachments {
+			if a, ok := v.(map[string]any); ok {
+				lowerString(a, "sha2")
+				normalizeLanguageMap(a["display"])
+				normalizeLanguageMap(a["description"])
+			}
+		}
+
+		sortByKey(attachments)
+	}
+}
+
+func normalizeActor(v any) {
+	m, ok := v.(map[string]any)
+
+	if !ok {
+		return
+	}
+
+	if t, _ := m["objectType"].(string); len(t) == 0 {
+		m["objectType"] = "Agent"
+	}
+
+	lowerString(m, "mbox_sha1sum")
+
+	if members, ok := m["members"].([]any); ok {
+		for _, member := range members {
+			normalizeActor(member)
+		}
+
+		sortByKey(members)
+	}
+}
+
+func normalizeObject(v any) {
+	m, ok := v.(map[string]any)
+
+	if !ok {
+		return
+	}
+
+	if t, _ := m["objectType"].(string); len(t) == 0 {
+		m["objectType"] = "Activity"
+	}
+
+	switch m["objectType"] {
+	case "Agent", "Group":
+		normalizeActor(m)
+	case "StatementRef":
+		lowerString(m, "id")
+	case "SubStatement":
+		normalizeStatement(m)
+	case "Activity":
+		def, ok := m["definition"].(map[string]any)
+
+		if !ok {
+			return
+		}
+
+		normalizeLanguageMap(def["name"])
+		normalizeLanguageMap(def["description"])
+
+		for _, key := range []string{"choices", "scale", "source", "target", "steps"} {
+			if components, ok := def[key].([]any); ok {
+				for _, c := range components {
+					if component, ok := c.(map[string]any); ok {
+						normalizeLanguageMap(component["description"])
+					}
+				}
+			}
+		}
+	}
+}
+
+// Language tags are case insensitive
+func normalizeLanguageMap(v any) {
+	m, ok := v.(map[string]any)
+
+	if !ok {
+		return
+	}
+
+	for k, value := range m {
+		if lower := strings.ToLower(k); lower != k {
+			delete(m, k)
+			m[lower] = value
+		}
+	}
+}
+
+func lowerString(m map[string]any, key string) {
+	if s, ok := m[key].(string); ok {
+		m[key] = strings.ToLower(s)
+	}
+}
+
+// sortByKey orders a list of normalized values by their serialization
+func sortByKey(list []any) {
+	keys := make(map[int]string, len(list))
+
+	for i, v := range list {
+		b, _ := json.Marshal(v)
+		keys[i] = string(b)
+	}
+
+	indices := make([]int, len(list))
+
+	for i := range indices {
+		indices[i] = i
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		return keys[indices[i]] < keys[indices[j]]
+	})
+
+	sorted := make([]any, len(list))
+
+	for i, idx := range indices {
+		sorted[i] = list[idx]
+	}
+
+	copy(list, sorted)
+}
+
+func compare(path string, a any, b any, diffs *[]Difference) {
+	ma, aok := a.(map[string]any)
+	mb, bok := b.(map[string]any)
+
+	if aok && bok {
+		keys := make(map[string]bool)
+
+		for k := range ma {
+			keys[k] = true
+		}
+
+		for k := range mb {
+			keys[k] = true
+		}
+
+		sorted := make([]string, 0, len(keys))
+
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			compare(join(path, k), ma[k], mb[k], diffs)
+		}
+
+		return
+	}
+
+	la, aok := a.([]any)
+	lb, bok := b.([]any)
+
+	if aok && bok && len(la) == len(lb) {
+		for i := range la {
+			compare(join(path, fmt.Sprint(i)), la[i], lb[i], diffs)
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, Difference{Path: path, A: a, B: b})
+	}
+}
+
+func join(path string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -1,8 +1,225 @@
 package statement
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
 // Extensions are available as part of Activity Definitions, as part of a Statement's "context" property,
 // or as part of a Statement's "result" property. In each case, extensions are intended to provide a
 // natural way to extend those properties for some specialized use. The contents of these extensions might
 // be something valuable to just one application, or it might be a convention used by an entire Community of Practice.
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#41-extensions
 type Extensions map[string]interface{}
+
+// Unmarshals the extensions, keeping numbers as json.Number so that integers above 2^53 aren't rounded to a float64
+func (e *Extensions) UnmarshalJSON(data []byte) error {
+	var m map[string]interface{}
+
+	if err := decodeNumbers(data, &m); err != nil {
+		return err
+	}
+
+	*e = m
+
+	return nil
+}
+
+// ErrExtensionNotFound is returned when an extension isn't present
+var ErrExtensionNotFound = errors.New("extension not found")
+
+// GetExtension returns the value of an extension converted to T. Values decoded from JSON are converted through
+// their JSON representation, so a number can be read as an int and an object as a struct. Numbers decoded from JSON
+// are held as json.Number, which is what T = interface{} returns.
+func GetExtension[T any](ext *Extensions, iri string) (T, error) {
+	var value T
+
+	if ext == nil {
+		return value, ErrExtensionNotFound
+	}
+
+	raw, ok := (*ext)[iri]
+
+	if !ok {
+		return value, ErrExtensionNotFound
+	}
+
+	if err := convertExtension(raw, &value); err != nil {
+		return value, fmt.Errorf("failed to convert extension %s: %w", iri, err)
+	}
+
+	return value, nil
+}
+
+// SetExtension sets the value of an extension, creating the extensions when nil, and returns them
+func SetExtension[T any](ext *Extensions, iri string, value T) *Extensions {
+	if ext == nil {
+		ext = &Extensions{}
+	}
+
+	if *ext == nil {
+		*ext = Extensions{}
+	}
+
+	(*ext)[iri] = value
+
+	return ext
+}
+
+// Converts an extension value to the type pointed by target
+func convertExtension(raw interface{}, target interface{}) error {
+	if v, ok := target.(*interface{}); ok {
+		*v = raw
+		return nil
+	}
+
+	b, err := json.Marshal(raw)
+
+	if err != nil {
+		return err
+	}
+
+	return decodeNumbers(b, target)
+}
+
+// Decodes JSON into v, numbers decoded into an interface{} are kept as json.Number
+func decodeNumbers(data []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+
+	if d.More() {
+		return errors.New("unexpected data after the JSON value")
+	}
+
+	return nil
+}
+
+// ValidationError describes a single problem found while validating a resource
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors is the list of problems found while validating a resource
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Returns the errors as an error, or nil when there are none
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// ExtensionRegistry holds the declared extension IRIs along with the validation of their values
+type ExtensionRegistry struct {
+	mu         sync.RWMutex
+	extensions map[string]func(interface{}) error
+}
+
+// DefaultExtensionRegistry is the registry used by the Validate methods when no registry is given
+var DefaultExtensionRegistry = NewExtensionRegistry()
+
+// NewExtensionRegistry creates an empty extension registry
+func NewExtensionRegistry() *ExtensionRegistry {
+	return &ExtensionRegistry{extensions: map[string]func(interface{}) error{}}
+}
+
+// RegisterExtension declares an extension whose value must convert to T and pass the validators
+func RegisterExtension[T any](registry *ExtensionRegistry, iri string, validators ...func(T) error) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.extensions[iri] = func(raw interface{}) error {
+		var value T
+
+		if err := convertExtension(raw, &value); err != nil {
+			return fmt.Errorf("expected %T: %w", value, err)
+		}
+
+		for _, validator := range validators {
+			if err := validator(value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Validate checks that every key is an IRI and that registered extensions hold valid values.
+// Unregistered extensions are accepted.
+func (r *ExtensionRegistry) Validate(ext *Extensions) error {
+	return r.validate(ext, "extensions").err()
+}
+
+// Validates the extensions, prefixing the paths of the errors with path
+func (r *ExtensionRegistry) validate(ext *Extensions, path string) ValidationErrors {
+	var errs ValidationErrors
+
+	if ext == nil {
+		return errs
+	}
+
+	keys := make([]string, 0, len(*ext))
+
+	for k := range *ext {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, k := range keys {
+		p := fmt.Sprintf("%s[%q]", path, k)
+
+		if u, err := url.Parse(k); err != nil || !u.IsAbs() {
+			errs = append(errs, ValidationError{Path: p, Message: "key is not an IRI"})
+			continue
+		}
+
+		if check, ok := r.extensions[k]; ok {
+			if err := check((*ext)[k]); err != nil {
+				errs = append(errs, ValidationError{Path: p, Message: err.Error()})
+			}
+		}
+	}
+
+	return errs
+}
+
+// Returns the registry to validate with
+func extensionRegistry(registry []*ExtensionRegistry) *ExtensionRegistry {
+	if len(registry) == 1 && registry[0] != nil {
+		return registry[0]
+	}
+
+	return DefaultExtensionRegistry
+}
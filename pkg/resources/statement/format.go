@@ -0,0 +1,305 @@
+package statement
+
+import (
+	"sort"
+	"strings"
+)
+
+// ToIDsFormat returns a copy of the statement reduced to the ids format: Agents, Groups, Activities and Verbs only
+// keep the properties needed to identify them. The statement itself is left untouched.
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Communication.md#213-get-statements
+func (s Statement) ToIDsFormat() *Statement {
+	stmt := s
+
+	stmt.Actor = idsActor(s.Actor)
+	stmt.Verb = Verb{ID: s.Verb.ID}
+	stmt.Object = idsObject(s.Object)
+	stmt.Authority = idsActor(s.Authority)
+
+	if s.Context != nil {
+		ctx := *s.Context
+
+		ctx.Instructor = idsActor(s.Context.Instructor)
+
+		if s.Context.Team != nil {
+			ctx.Team = idsGroup(*s.Context.Team)
+		}
+
+		if s.Context.ContextActivities != nil {
+			ctx.ContextActivities = mapContextActivities(*s.Context.ContextActivities, idsActivity)
+		}
+
+		stmt.Context = &ctx
+	}
+
+	return &stmt
+}
+
+func idsAgent(a Agent) *Agent {
+	return &Agent{
+		ObjectType:  a.ObjectType,
+		Mbox:        a.Mbox,
+		MboxSHA1Sum: a.MboxSHA1Sum,
+		OpenID:      a.OpenID,
+		Account:     a.Account,
+	}
+}
+
+// Identified groups keep their IFI, anonymous groups are identified by their members
+func idsGroup(g Group) *Group {
+	group := &Group{ObjectType: g.ObjectType}
+
+	if g.IsIdentified() {
+		group.Mbox = g.Mbox
+		group.MboxSHA1Sum = g.MboxSHA1Sum
+		group.OpenID = g.OpenID
+		group.Account = g.Account
+
+		return group
+	}
+
+	for _, m := range g.Members {
+		group.Members = append(group.Members, *idsAgent(m))
+	}
+
+	return group
+}
+
+func idsActor(actor IActor) IActor {
+	switch v := actor.(type) {
+	case Agent:
+		return idsAgent(v)
+	case *Agent:
+		return idsAgent(*v)
+	case Group:
+		return idsGroup(v)
+	case *Group:
+		return idsGroup(*v)
+	}
+
+	return actor
+}
+
+func idsActivity(a Activity) Activity {
+	return Activity{ID: a.ID, ObjectType: a.ObjectType}
+}
+
+func idsSubStatement(s SubStatement) *SubStatement {
+	sub := s
+	sub.Statement = *s.Statement.ToIDsFormat()
+
+	return &sub
+}
+
+func idsObject(object IObject) IObject {
+	switch v := object.(type) {
+	case Activity:
+		a := idsActivity(v)
+		return &a
+	case *Activity:
+		a := idsActivity(*v)
+		return &a
+	case Agent, *Agent, Group, *Group:
+		return idsActor(v.(IActor)).(IObject)
+	case SubStatement:
+		return idsSubStatement(v)
+	case *SubStatement:
+		return idsSubStatement(*v)
+	}
+
+	return object
+}
+
+func mapContextActivities(c ContextActivities, fn func(Activity) Activity) *ContextActivities {
+	apply := func(list []Activity) []Activity {
+		if list == nil {
+			return nil
+		}
+
+		mapped := make([]Activity, len(list))
+
+		for i, a := range list {
+			mapped[i] = fn(a)
+		}
+
+		return mapped
+	}
+
+	return &ContextActivities{
+		Parent:   apply(c.Parent),
+		Grouping: apply(c.Grouping),
+		Category: apply(c.Category),
+		Other:    apply(c.Other),
+	}
+}
+
+// Canonicalize returns a copy of the statement where every language map only holds the entry best matching
+// the given language ranges, in order of preference, as the canonical format does. The statement itself is left untouched.
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Communication.md#213-get-statements
+func (s Statement) Canonicalize(langs []string) *Statement {
+	stmt := s
+
+	stmt.Verb = Verb{ID: s.Verb.ID, Display: canonicalLanguageMap(s.Verb.Display, langs)}
+	stmt.Object = canonicalObject(s.Object, langs)
+
+	if s.Context != nil && s.Context.ContextActivities != nil {
+		ctx := *s.Context
+		ctx.ContextActivities = mapContextActivities(*s.Context.ContextActivities, func(a Activity) Activity {
+			return canonicalActivity(a, langs)
+		})
+
+		stmt.Context = &ctx
+	}
+
+	if s.Attachments != nil {
+		stmt.Attachments = make([]Attachment, len(s.Attachments))
+
+		for i, a := range s.Attachments {
+			a.Display = canonicalLanguageMap(a.Display, langs)
+
+			if a.Description != nil {
+				a.Description = canonicalLanguageMapPtr(a.Description, langs)
+			}
+
+			stmt.Attachments[i] = a
+		}
+	}
+
+	return &stmt
+}
+
+func canonicalObject(object IObject, langs []string) IObject {
+	switch v := object.(type) {
+	case Activity:
+		a := canonicalActivity(v, langs)
+		return &a
+	case *Activity:
+		a := canonicalActivity(*v, langs)
+		return &a
+	case SubStatement:
+		v.Statement = *v.Statement.Canonicalize(langs)
+		return &v
+	case *SubStatement:
+		sub := *v
+		sub.Statement = *v.Statement.Canonicalize(langs)
+		return &sub
+	}
+
+	return object
+}
+
+func canonicalActivity(a Activity, langs []string) Activity {
+	if a.Definition == nil {
+		return a
+	}
+
+	def := *a.Definition
+
+	def.Name = canonicalLanguageMapPtr(def.Name, langs)
+	def.Description = canonicalLanguageMapPtr(def.Description, langs)
+	def.Choices = canonicalComponents(def.Choices, langs)
+	def.Scale = canonicalComponents(def.Scale, langs)
+	def.Source = canonicalComponents(def.Source, langs)
+	def.Target = canonicalComponents(def.Target, langs)
+	def.Steps = canonicalComponents(def.Steps, langs)
+
+	a.Definition = &def
+
+	return a
+}
+
+func canonicalComponents(components []InteractionComponent, langs []string) []InteractionComponent {
+	if components == nil {
+		return nil
+	}
+
+	mapped := make([]InteractionComponent, len(components))
+
+	for i, c := range components {
+		c.Description = canonicalLanguageMapPtr(c.Description, langs)
+		mapped[i] = c
+	}
+
+	return mapped
+}
+
+func canonicalLanguageMapPtr(m *LanguageMap, langs []string) *LanguageMap {
+	if m == nil {
+		return nil
+	}
+
+	canonical := canonicalLanguageMap(*m, langs)
+
+	return &canonical
+}
+
+func canonicalLanguageMap(m LanguageMap, langs []string) LanguageMap {
+	if len(m) == 0 {
+		return m
+	}
+
+	key, ok := lookupLanguage(m, langs)
+
+	if !ok {
+		key = defaultLanguage(m)
+	}
+
+	return LanguageMap{key: m[key]}
+}
+
+// lookupLanguage finds the key of the language map matching the language ranges using the RFC 4647 lookup scheme
+// https://www.rfc-editor.org/rfc/rfc4647#section-3.4
+func lookupLanguage(m LanguageMap, ranges []string) (string, bool) {
+	keys := make(map[string]string, len(m))
+
+	for k := range m {
+		keys[strings.ToLower(k)] = k
+	}
+
+	for _, r := range ranges {
+		tag := strings.ToLower(strings.TrimSpace(r))
+
+		if tag == "*" {
+			continue
+		}
+
+		for len(tag) > 0 {
+			if k, ok := keys[tag]; ok {
+				return k, true
+			}
+
+			i := strings.LastIndex(tag, "-")
+
+			if i < 0 {
+				break
+			}
+
+			tag = tag[:i]
+
+			// Singletons such as x- can't end a tag
+			if j := strings.LastIndex(tag, "-"); j >= 0 && len(tag)-j == 2 {
+				tag = tag[:j]
+			}
+		}
+	}
+
+	return "", false
+}
+
+// defaultLanguage picks an entry when none of the preferred languages are available.
+// The undetermined language is preferred, otherwise the choice is deterministic.
+func defaultLanguage(m LanguageMap) string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		if strings.EqualFold(k, "und") {
+			return k
+		}
+
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys[0]
+}
@@ -4,9 +4,13 @@ package statement
 // There are two types of Groups: Anonymous Groups and Identified Groups.
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#group
 type Group struct {
-	ObjectType string  `json:"objectType" xapi:"required"`
-	Name       *string `json:"name,omitempty" xapi:"optional"`
-	Members    []Agent `json:"members" xapi:"required"`
+	ObjectType  string   `json:"objectType" xapi:"required"`
+	Name        *string  `json:"name,omitempty" xapi:"optional"`
+	Members     []Agent  `json:"members,omitempty" xapi:"optional"`
+	Mbox        *string  `json:"mbox,omitempty" xapi:"optional"`
+	MboxSHA1Sum *string  `json:"mbox_sha1sum,omitempty" xapi:"optional"`
+	OpenID      *string  `json:"openid,omitempty" xapi:"optional"`
+	Account     *Account `json:"account,omitempty" xapi:"optional"`
 }
 
 // Returns Group
@@ -24,6 +28,108 @@ func (g *Group) AddMember(agent Agent) {
 	g.Members = append(g.Members, agent)
 }
 
+// Adds a new member to the group unless an agent with the same IFI is already a member. Returns whether it was added.
+func (g *Group) AddUniqueMember(agent Agent) bool {
+	key := memberKey(agent)
+
+	for _, m := range g.Members {
+		if memberKey(m) == key {
+			return false
+		}
+	}
+
+	g.Members = append(g.Members, agent)
+	return true
+}
+
+// Removes members with the same IFI, keeping the first occurrence
+func (g *Group) DedupeMembers() {
+	if g.Members == nil {
+		return
+	}
+
+	seen := make(map[string]bool, len(g.Members))
+	members := make([]Agent, 0, len(g.Members))
+
+	for _, m := range g.Members {
+		key := memberKey(m)
+
+		if !seen[key] {
+			seen[key] = true
+			members = append(members, m)
+		}
+	}
+
+	g.Members = members
+}
+
+// IsIdentified reports whether the group has an IFI. Groups without one are anonymous.
+func (g Group) IsIdentified() bool {
+	return g.Mbox != nil || g.MboxSHA1Sum != nil || g.OpenID != nil || g.Account != nil
+}
+
+// IFI returns the inverse functional identifier of an identified group
+func (g Group) IFI() (IFI, error) {
+	return ifiOf(g.Mbox, g.MboxSHA1Sum, g.OpenID, g.Account)
+}
+
+// Key returns a string identifying an identified group, suitable as a map key. It is empty for anonymous groups.
+func (g Group) Key() string {
+	ifi, err := g.IFI()
+
+	if err != nil {
+		return ""
+	}
+
+	return keyOf(ifi)
+}
+
+// Returns the key members are compared by. Members without a valid IFI are compared by their JSON.
+func memberKey(a Agent) string {
+	if key := a.Key(); key != "" {
+		return key
+	}
+
+	return a.ToJSON()
+}
+
+// ExpandActor returns the agents an actor stands for: the agent itself, or the distinct members of a group
+func ExpandActor(actor IActor) []Agent {
+	var group Group
+
+	switch v := actor.(type) {
+	case *Agent:
+		return []Agent{*v}
+	case Agent:
+		return []Agent{v}
+	case *Group:
+		group = *v
+	case Group:
+		group = v
+	default:
+		return nil
+	}
+
+	group.Members = append([]Agent(nil), group.Members...)
+	group.DedupeMembers()
+
+	return group.Members
+}
+
+// ExpandStatement returns a copy of the statement for each agent its actor stands for, with the agent as actor.
+// Statements with a group actor are reported per learner this way.
+func ExpandStatement(stmt Statement) []Statement {
+	agents := ExpandActor(stmt.Actor)
+	expanded := make([]Statement, len(agents))
+
+	for i := range agents {
+		expanded[i] = stmt
+		expanded[i].Actor = &agents[i]
+	}
+
+	return expanded
+}
+
 // Creates a new group
 func NewGroup(name string) *Group {
 	return &Group{
@@ -32,6 +138,44 @@ func NewGroup(name string) *Group {
 	}
 }
 
+// Creates a new identified group with a mailbox. The mailto: scheme is added when missing.
+func NewGroupWithMbox(name string, mbox string) *Group {
+	mbox = prefixMbox(mbox)
+
+	return &Group{
+		ObjectType: "Group",
+		Name:       &name,
+		Mbox:       &mbox,
+	}
+}
+
+// Creates a new identified group with a mail SHA1
+func NewGroupWithSHA1(name string, sha1 string) *Group {
+	return &Group{
+		ObjectType:  "Group",
+		Name:        &name,
+		MboxSHA1Sum: &sha1,
+	}
+}
+
+// Creates a new identified group with an openid
+func NewGroupWithOpenID(name string, openid string) *Group {
+	return &Group{
+		ObjectType: "Group",
+		Name:       &name,
+		OpenID:     &openid,
+	}
+}
+
+// Creates a new identified group with an account
+func NewGroupWithAccount(name string, account *Account) *Group {
+	return &Group{
+		ObjectType: "Group",
+		Name:       &name,
+		Account:    account,
+	}
+}
+
 // Creates a new anonymous group
 func NewAnonymousGroup() *Group {
 	return &Group{
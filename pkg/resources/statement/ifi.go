@@ -0,0 +1,171 @@
+package statement
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// IFIType is the kind of an inverse functional identifier
+type IFIType string
+
+// Inverse functional identifier types
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#inversefunctional
+const (
+	IFIMbox        IFIType = "mbox"
+	IFIMboxSHA1Sum IFIType = "mbox_sha1sum"
+	IFIOpenID      IFIType = "openid"
+	IFIAccount     IFIType = "account"
+)
+
+// IFI is an inverse functional identifier, which uniquely identifies an Agent or an identified Group.
+// For accounts, Value holds the account name and HomePage the home page. IFIs are comparable and can be used as map keys.
+type IFI struct {
+	Type     IFIType
+	Value    string
+	HomePage string
+}
+
+// ErrNoIFI is returned when an agent or group has no inverse functional identifier
+var ErrNoIFI = errors.New("no inverse functional identifier")
+
+// ErrMultipleIFI is returned when an agent or group has more than one inverse functional identifier
+var ErrMultipleIFI = errors.New("more than one inverse functional identifier")
+
+// String returns a key uniquely representing the IFI
+func (i IFI) String() string {
+	if i.Type == IFIAccount {
+		return string(i.Type) + ":" + i.HomePage + "|" + i.Value
+	}
+
+	return string(i.Type) + ":" + i.Value
+}
+
+// NormalizeMbox prefixes a mailbox with mailto: when missing and checks it holds a valid address
+func NormalizeMbox(mbox string) (string, error) {
+	mbox = strings.TrimSpace(mbox)
+
+	if len(mbox) >= 7 && strings.EqualFold(mbox[:7], "mailto:") {
+		mbox = mbox[7:]
+	}
+
+	addr, err := mail.ParseAddress(mbox)
+
+	if err != nil || addr.Address != mbox {
+		return "", fmt.Errorf("invalid mbox %q", mbox)
+	}
+
+	return "mailto:" + mbox, nil
+}
+
+// MboxSHA1Sum returns the hex encoded SHA1 of a mailbox, including its mailto: scheme, as required by mbox_sha1sum
+func MboxSHA1Sum(mbox string) (string, error) {
+	mbox, err := NormalizeMbox(mbox)
+
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha1.Sum([]byte(mbox))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Returns the mailbox with the mailto: scheme, leaving invalid values for IFI to report
+func prefixMbox(mbox string) string {
+	if normalized, err := NormalizeMbox(mbox); err == nil {
+		return normalized
+	}
+
+	return mbox
+}
+
+// Returns the single IFI among the given properties
+func ifiOf(mbox *string, mboxSHA1Sum *string, openID *string, account *Account) (IFI, error) {
+	var ifis []IFI
+
+	if mbox != nil {
+		normalized, err := NormalizeMbox(*mbox)
+
+		if err != nil {
+			return IFI{}, err
+		}
+
+		ifis = append(ifis, IFI{Type: IFIMbox, Value: normalized})
+	}
+
+	if mboxSHA1Sum != nil {
+		ifis = append(ifis, IFI{Type: IFIMboxSHA1Sum, Value: strings.ToLower(*mboxSHA1Sum)})
+	}
+
+	if openID != nil {
+		ifis = append(ifis, IFI{Type: IFIOpenID, Value: *openID})
+	}
+
+	if account != nil {
+		ifis = append(ifis, IFI{Type: IFIAccount, Value: account.Name, HomePage: account.HomePage})
+	}
+
+	switch len(ifis) {
+	case 0:
+		return IFI{}, ErrNoIFI
+	case 1:
+		return ifis[0], nil
+	}
+
+	return IFI{}, ErrMultipleIFI
+}
+
+// Returns the IFI used as a key. Mailboxes are keyed by their SHA1 so an agent and its anonymized form share a key.
+func keyOf(ifi IFI) string {
+	if ifi.Type == IFIMbox {
+		sum := sha1.Sum([]byte(ifi.Value))
+		ifi = IFI{Type: IFIMboxSHA1Sum, Value: hex.EncodeToString(sum[:])}
+	}
+
+	return ifi.String()
+}
+
+// IFI returns the inverse functional identifier of the agent. An agent must have exactly one.
+func (a Agent) IFI() (IFI, error) {
+	return ifiOf(a.Mbox, a.MboxSHA1Sum, a.OpenID, a.Account)
+}
+
+// Anonymize returns a copy of the agent with its mailbox replaced by mbox_sha1sum and without a name
+func (a Agent) Anonymize() (*Agent, error) {
+	anonymized := a
+	anonymized.Name = nil
+
+	if a.Mbox != nil {
+		sum, err := MboxSHA1Sum(*a.Mbox)
+
+		if err != nil {
+			return nil, err
+		}
+
+		anonymized.Mbox = nil
+		anonymized.MboxSHA1Sum = &sum
+	}
+
+	return &anonymized, nil
+}
+
+// Key returns a string identifying the agent, suitable as a map key. It is empty when the agent has no valid IFI.
+func (a Agent) Key() string {
+	ifi, err := a.IFI()
+
+	if err != nil {
+		return ""
+	}
+
+	return keyOf(ifi)
+}
+
+// Equal reports whether both agents have the same IFI. A mailbox equals its mbox_sha1sum.
+func (a Agent) Equal(other Agent) bool {
+	key := a.Key()
+	return key != "" && key == other.Key()
+}
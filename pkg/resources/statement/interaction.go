@@ -0,0 +1,248 @@
+package statement
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+)
+
+// Delimiters used in correct responses patterns
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#correct-responses-pattern
+const (
+	ResponseDelimiter = "[,]"
+	PairDelimiter     = "[.]"
+	RangeDelimiter    = "[:]"
+)
+
+// Optional parameters of the interaction builders
+type InteractionOptions struct {
+	Name         *LanguageMap
+	Description  *LanguageMap
+	CaseMatters  *bool
+	OrderMatters *bool
+}
+
+// Creates the definition shared by all interaction builders
+func newInteraction(interactionType string, params []*InteractionOptions) *ActivityDefinition {
+	ad := ActivityDefinition{
+		Type:            utils.Ptr(ActivityTypeCMIInteraction),
+		InteractionType: utils.Ptr(interactionType),
+	}
+
+	if len(params) == 1 && params[0] != nil {
+		ad.Name = params[0].Name
+		ad.Description = params[0].Description
+	}
+
+	return &ad
+}
+
+// Returns the pattern prefix for the case_matters and order_matters parameters the interaction type supports
+func patternFlags(params []*InteractionOptions, caseMatters bool, orderMatters bool) string {
+	var flags string
+
+	if len(params) == 1 && params[0] != nil {
+		if caseMatters && params[0].CaseMatters != nil {
+			flags += fmt.Sprintf("{case_matters=%t}", *params[0].CaseMatters)
+		}
+
+		if orderMatters && params[0].OrderMatters != nil {
+			flags += fmt.Sprintf("{order_matters=%t}", *params[0].OrderMatters)
+		}
+	}
+
+	return flags
+}
+
+// Checks that every id belongs to one of the components
+func checkComponents(ids []string, components []InteractionComponent, kind string) error {
+	for _, id := range ids {
+		found := false
+
+		for _, c := range components {
+			if c.ID == id {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("unknown %s %q", kind, id)
+		}
+	}
+
+	return nil
+}
+
+// NumericRange formats a numeric range for a correct responses pattern. A nil bound leaves that side open.
+func NumericRange(min *float64, max *float64) string {
+	format := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	}
+
+	if min != nil && max != nil && *min == *max {
+		return format(min)
+	}
+
+	return format(min) + RangeDelimiter + format(max)
+}
+
+// NewTrueFalseInteraction creates a true-false interaction
+func NewTrueFalseInteraction(correct bool, params ...*InteractionOptions) *ActivityDefinition {
+	ad := newInteraction(InteractionTrueFalse, params)
+	ad.CorrectResponsesPattern = []string{strconv.FormatBool(correct)}
+
+	return ad
+}
+
+// NewChoiceInteraction creates a choice interaction where the correct response is the set of correct choice ids
+func NewChoiceInteraction(choices []InteractionComponent, correct []string, params ...*InteractionOptions) (*ActivityDefinition, error) {
+	if err := checkComponents(correct, choices, "choice"); err != nil {
+		return nil, err
+	}
+
+	ad := newInteraction(InteractionChoice, params)
+	ad.Choices = choices
+
+	if len(correct) > 0 {
+		ad.CorrectResponsesPattern = []string{strings.Join(correct, ResponseDelimiter)}
+	}
+
+	return ad, nil
+}
+
+// NewFillInInteraction creates a fill-in interaction. CaseMatters and OrderMatters are added to the pattern when set.
+func NewFillInInteraction(correct []string, params ...*InteractionOptions) *ActivityDefinition {
+	ad := newInteraction(InteractionFillIn, params)
+
+	if len(correct) > 0 {
+		ad.CorrectResponsesPattern = []string{patternFlags(params, true, true) + strings.Join(correct, ResponseDelimiter)}
+	}
+
+	return ad
+}
+
+// NewLongFillInInteraction creates a long-fill-in interaction. CaseMatters is added to the pattern when set.
+func NewLongFillInInteraction(correct []string, params ...*InteractionOptions) *ActivityDefinition {
+	ad := newInteraction(InteractionLongFillIn, params)
+
+	if len(correct) > 0 {
+		ad.CorrectResponsesPattern = []string{patternFlags(params, true, false) + strings.Join(correct, ResponseDelimiter)}
+	}
+
+	return ad
+}
+
+// NewMatchingInteraction creates a matching interaction. Each correct pair holds a source id and a target id.
+func NewMatchingInteraction(source []InteractionComponent, target []InteractionComponent, correct [][2]string, params ...*InteractionOptions) (*ActivityDefinition, error) {
+	pairs := make([]string, len(correct))
+
+	for i, pair := range correct {
+		if err := checkComponents(pair[:1], source, "source"); err != nil {
+			return nil, err
+		}
+
+		if err := checkComponents(pair[1:], target, "target"); err != nil {
+			return nil, err
+		}
+
+		pairs[i] = pair[0] + PairDelimiter + pair[1]
+	}
+
+	ad := newInteraction(InteractionMatching, params)
+	ad.Source = source
+	ad.Target = target
+
+	if len(pairs) > 0 {
+		ad.CorrectResponsesPattern = []string{strings.Join(pairs, ResponseDelimiter)}
+	}
+
+	return ad, nil
+}
+
+// NewPerformanceInteraction creates a performance interaction. Each correct pair holds a step id and its response,
+// which can be a numeric range built with NumericRange. OrderMatters is added to the pattern when set.
+func NewPerformanceInteraction(steps []InteractionComponent, correct [][2]string, params ...*InteractionOptions) (*ActivityDefinition, error) {
+	pairs := make([]string, len(correct))
+
+	for i, pair := range correct {
+		if err := checkComponents(pair[:1], steps, "step"); err != nil {
+			return nil, err
+		}
+
+		pairs[i] = pair[0] + PairDelimiter + pair[1]
+	}
+
+	ad := newInteraction(InteractionPerformance, params)
+	ad.Steps = steps
+
+	if len(pairs) > 0 {
+		ad.CorrectResponsesPattern = []string{patternFlags(params, false, true) + strings.Join(pairs, ResponseDelimiter)}
+	}
+
+	return ad, nil
+}
+
+// NewSequencingInteraction creates a sequencing interaction where the correct response is the ordered list of choice ids
+func NewSequencingInteraction(choices []InteractionComponent, correct []string, params ...*InteractionOptions) (*ActivityDefinition, error) {
+	if err := checkComponents(correct, choices, "choice"); err != nil {
+		return nil, err
+	}
+
+	ad := newInteraction(InteractionSequencing, params)
+	ad.Choices = choices
+
+	if len(correct) > 0 {
+		ad.CorrectResponsesPattern = []string{strings.Join(correct, ResponseDelimiter)}
+	}
+
+	return ad, nil
+}
+
+// NewLikertInteraction creates a likert interaction. An empty correct id leaves the pattern unset.
+func NewLikertInteraction(scale []InteractionComponent, correct string, params ...*InteractionOptions) (*ActivityDefinition, error) {
+	ad := newInteraction(InteractionLikert, params)
+	ad.Scale = scale
+
+	if correct != "" {
+		if err := checkComponents([]string{correct}, scale, "scale"); err != nil {
+			return nil, err
+		}
+
+		ad.CorrectResponsesPattern = []string{correct}
+	}
+
+	return ad, nil
+}
+
+// NewNumericInteraction creates a numeric interaction with the inclusive range of correct responses
+func NewNumericInteraction(min *float64, max *float64, params ...*InteractionOptions) (*ActivityDefinition, error) {
+	if min != nil && max != nil && *min > *max {
+		return nil, fmt.Errorf("min %v is greater than max %v", *min, *max)
+	}
+
+	ad := newInteraction(InteractionNumeric, params)
+
+	if min != nil || max != nil {
+		ad.CorrectResponsesPattern = []string{NumericRange(min, max)}
+	}
+
+	return ad, nil
+}
+
+// NewOtherInteraction creates an interaction of type other with the given correct responses
+func NewOtherInteraction(correct []string, params ...*InteractionOptions) *ActivityDefinition {
+	ad := newInteraction(InteractionOther, params)
+
+	if len(correct) > 0 {
+		ad.CorrectResponsesPattern = []string{strings.Join(correct, ResponseDelimiter)}
+	}
+
+	return ad
+}
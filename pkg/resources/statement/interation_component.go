@@ -3,7 +3,7 @@ package statement
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#interaction-components
 type InteractionComponent struct {
 	ID          string       `json:"id" xapi:"required"`
-	Description *LanguageMap `json:"description" xapi:"optional"`
+	Description *LanguageMap `json:"description,omitempty" xapi:"optional"`
 }
 
 // Creates a new interaction component
@@ -1,6 +1,102 @@
 package statement
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
 // A language map is a dictionary where the key is a RFC 5646 Language Tag, and the value is a string in the language specified in the tag.
 // This map SHOULD be populated as fully as possible based on the knowledge of the string in question in different languages.
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#42-language-maps
 type LanguageMap map[string]string
+
+// Best returns the entry best matching the preferred languages using BCP 47 matching.
+// If none of them match, the undetermined entry or else a deterministic one is returned.
+func (l LanguageMap) Best(preferred ...language.Tag) string {
+	if len(l) == 0 {
+		return ""
+	}
+
+	// The first supported tag is the matcher's fallback
+	keys := []string{defaultLanguage(l)}
+
+	for k := range l {
+		if k != keys[0] {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys[1:])
+
+	var supported []string
+	var tags []language.Tag
+
+	for _, k := range keys {
+		if tag, err := language.Parse(k); err == nil {
+			supported = append(supported, k)
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) == 0 || len(preferred) == 0 {
+		return l[keys[0]]
+	}
+
+	_, i, confidence := language.NewMatcher(tags).Match(preferred...)
+
+	if confidence == language.No {
+		return l[keys[0]]
+	}
+
+	return l[supported[i]]
+}
+
+// Validate checks that every key is a well-formed RFC 5646 language tag
+func (l LanguageMap) Validate() error {
+	var invalid []string
+
+	for k := range l {
+		if !validLanguageTag(k) {
+			invalid = append(invalid, k)
+		}
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	sort.Strings(invalid)
+
+	return fmt.Errorf("invalid language tags: %s", strings.Join(invalid, ", "))
+}
+
+// Merge returns a new language map holding the entries of both maps. Entries of other replace the entries
+// with the same language tag, compared case insensitively.
+func (l LanguageMap) Merge(other LanguageMap) LanguageMap {
+	merged := make(LanguageMap, len(l)+len(other))
+
+	for k, v := range l {
+		merged[k] = v
+	}
+
+	for k, v := range other {
+		for existing := range merged {
+			if strings.EqualFold(existing, k) {
+				delete(merged, existing)
+			}
+		}
+
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// Checks that a string is a well-formed RFC 5646 language tag. The parser accepts underscores, which RFC 5646 doesn't.
+func validLanguageTag(tag string) bool {
+	_, err := language.Parse(tag)
+	return err == nil && !strings.Contains(tag, "_")
+}
@@ -20,12 +20,13 @@ func UnmarshalObject(m json.RawMessage, object *IObject) error {
 		return err
 	}
 
+	// The object type of an object defaults to Activity
 	switch obj.ObjectType {
 	case "Agent":
 		*object = new(Agent)
 	case "Group":
 		*object = new(Group)
-	case "Activity":
+	case "Activity", "":
 		*object = new(Activity)
 	case "StatementRef":
 		*object = new(StatementRef)
@@ -37,3 +38,23 @@ func UnmarshalObject(m json.RawMessage, object *IObject) error {
 
 	return json.Unmarshal(m, object)
 }
+
+// Returns the object with its object type set when it is an agent. The object type of an agent is optional as an
+// actor, but an object without one is an activity.
+func withObjectType(object IObject) IObject {
+	switch o := object.(type) {
+	case *Agent:
+		if o != nil && len(o.ObjectType) == 0 {
+			agent := *o
+			agent.ObjectType = "Agent"
+			return &agent
+		}
+	case Agent:
+		if len(o.ObjectType) == 0 {
+			o.ObjectType = "Agent"
+			return o
+		}
+	}
+
+	return object
+}
@@ -7,6 +7,21 @@ type Result struct {
 	Success    *bool       `json:"success,omitempty" xapi:"optional"`
 	Completion *bool       `json:"completion,omitempty" xapi:"optional"`
 	Response   *string     `json:"response,omitempty" xapi:"optional"`
-	Duration   *string     `json:"duration,omitempty" xapi:"optional"`
+	Duration   *Duration   `json:"duration,omitempty" xapi:"optional"`
 	Extensions *Extensions `json:"extensions,omitempty" xapi:"optional"`
 }
+
+// Validate checks the score and the extensions against the given registry, or the default registry
+func (r *Result) Validate(registry ...*ExtensionRegistry) error {
+	var errs ValidationErrors
+
+	if r.Score != nil {
+		if err := r.Score.Validate(); err != nil {
+			errs = append(errs, ValidationError{Path: "score", Message: err.Error()})
+		}
+	}
+
+	errs = append(errs, extensionRegistry(registry).validate(r.Extensions, "extensions")...)
+
+	return errs.err()
+}
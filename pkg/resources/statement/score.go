@@ -1,10 +1,84 @@
 package statement
 
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+)
+
 // An optional property that represents the outcome of a graded Activity achieved by an Agent.
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#2451-score
 type Score struct {
-	Scaled *float32 `json:"scaled,omitempty" xapi:"recommended"`
-	Raw    *float32 `json:"raw,omitempty" xapi:"optional"`
-	Min    *float32 `json:"min,omitempty" xapi:"optional"`
-	Max    *float32 `json:"max,omitempty" xapi:"optional"`
+	Scaled *float64 `json:"scaled,omitempty" xapi:"recommended"`
+	Raw    *float64 `json:"raw,omitempty" xapi:"optional"`
+	Min    *float64 `json:"min,omitempty" xapi:"optional"`
+	Max    *float64 `json:"max,omitempty" xapi:"optional"`
+}
+
+// NewScore creates a score from a raw value and its bounds. Scaled is derived by mapping [min, max] to [0, 1].
+func NewScore(raw float64, min float64, max float64) (*Score, error) {
+	score := &Score{
+		Raw: &raw,
+		Min: &min,
+		Max: &max,
+	}
+
+	if err := score.Validate(); err != nil {
+		return nil, err
+	}
+
+	score.Scaled = utils.Ptr((raw - min) / (max - min))
+
+	return score, nil
+}
+
+// NewScaledScore creates a score with only the scaled value set
+func NewScaledScore(scaled float64) (*Score, error) {
+	score := &Score{Scaled: &scaled}
+
+	if err := score.Validate(); err != nil {
+		return nil, err
+	}
+
+	return score, nil
+}
+
+// Validate checks the constraints of the spec: scaled is in [-1, 1], min is less than max and raw is within [min, max].
+// NaN values, which no comparison rejects, are invalid too.
+func (s Score) Validate() error {
+	var problems []string
+
+	for _, v := range []struct {
+		name  string
+		value *float64
+	}{{"scaled", s.Scaled}, {"raw", s.Raw}, {"min", s.Min}, {"max", s.Max}} {
+		if v.value != nil && math.IsNaN(*v.value) {
+			problems = append(problems, v.name+" is not a number")
+		}
+	}
+
+	if s.Scaled != nil && (*s.Scaled < -1 || *s.Scaled > 1) {
+		problems = append(problems, fmt.Sprintf("scaled %v is not between -1 and 1", *s.Scaled))
+	}
+
+	if s.Min != nil && s.Max != nil && *s.Min >= *s.Max {
+		problems = append(problems, fmt.Sprintf("min %v is not less than max %v", *s.Min, *s.Max))
+	}
+
+	if s.Raw != nil && s.Min != nil && *s.Raw < *s.Min {
+		problems = append(problems, fmt.Sprintf("raw %v is less than min %v", *s.Raw, *s.Min))
+	}
+
+	if s.Raw != nil && s.Max != nil && *s.Raw > *s.Max {
+		problems = append(problems, fmt.Sprintf("raw %v is greater than max %v", *s.Raw, *s.Max))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return errors.New("invalid score: " + strings.Join(problems, ", "))
 }
@@ -0,0 +1,311 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Parameters that can prefix a correct responses pattern
+type patternFlagSet struct {
+	caseMatters  bool
+	orderMatters bool
+}
+
+// CheckResponse decides whether a learner's response matches any of the correct responses patterns,
+// parsing both according to the syntax of the interaction type.
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#response-patterns
+func (ad *ActivityDefinition) CheckResponse(response string) (bool, error) {
+	if ad.InteractionType == nil {
+		return false, errors.New("activity definition has no interaction type")
+	}
+
+	if len(ad.CorrectResponsesPattern) == 0 {
+		return false, errors.New("activity definition has no correct responses pattern")
+	}
+
+	for _, pattern := range ad.CorrectResponsesPattern {
+		correct, err := matchResponse(*ad.InteractionType, pattern, response)
+
+		if err != nil {
+			return false, err
+		}
+
+		if correct {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// CheckResponse decides whether the response in the result of the statement is correct for its activity
+func (s Statement) CheckResponse() (bool, error) {
+	var activity *Activity
+
+	switch o := s.Object.(type) {
+	case *Activity:
+		activity = o
+	case Activity:
+		activity = &o
+	default:
+		return false, errors.New("statement object is not an activity")
+	}
+
+	if activity.Definition == nil {
+		return false, errors.New("activity has no definition")
+	}
+
+	if s.Result == nil || s.Result.Response == nil {
+		return false, errors.New("statement has no response")
+	}
+
+	return activity.Definition.CheckResponse(*s.Result.Response)
+}
+
+// Matches a response against a single pattern
+func matchResponse(interactionType string, pattern string, response string) (bool, error) {
+	switch interactionType {
+	case InteractionTrueFalse, InteractionLikert, InteractionOther:
+		return pattern == response, nil
+	case InteractionChoice, InteractionMatching:
+		return sameItems(splitResponse(pattern), splitResponse(response), false), nil
+	case InteractionSequencing:
+		return sameItems(splitResponse(pattern), splitResponse(response), true), nil
+	case InteractionNumeric:
+		return matchNumeric(pattern, response)
+	case InteractionFillIn, InteractionLongFillIn:
+		return matchFillIn(pattern, response), nil
+	case InteractionPerformance:
+		return matchPerformance(pattern, response)
+	}
+
+	return false, fmt.Errorf("unknown interaction type %q", interactionType)
+}
+
+// Splits a pattern or response into its items
+func splitResponse(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+
+	return strings.Split(s, ResponseDelimiter)
+}
+
+// Compares two lists of items, as sets unless order matters
+func sameItems(a []string, b []string, ordered bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	if !ordered {
+		a = append([]string(nil), a...)
+		b = append([]string(nil), b...)
+		sort.Strings(a)
+		sort.Strings(b)
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Strips the case_matters and order_matters parameters from the start of a pattern
+func parsePatternFlags(pattern string, flags patternFlagSet) (string, patternFlagSet) {
+	for {
+		var value *bool
+
+		switch {
+		case strings.HasPrefix(pattern, "{case_matters="):
+			value = &flags.caseMatters
+		case strings.HasPrefix(pattern, "{order_matters="):
+			value = &flags.orderMatters
+		default:
+			return pattern, flags
+		}
+
+		end := strings.Index(pattern, "}")
+
+		if end < 0 {
+			return pattern, flags
+		}
+
+		v, err := strconv.ParseBool(pattern[strings.Index(pattern, "=")+1 : end])
+
+		if err != nil {
+			return pattern, flags
+		}
+
+		*value = v
+		pattern = pattern[end+1:]
+	}
+}
+
+// Strips the lang parameter from the start of a fill-in item
+func stripLang(item string) string {
+	if strings.HasPrefix(item, "{lang=") {
+		if end := strings.Index(item, "}"); end >= 0 {
+			return item[end+1:]
+		}
+	}
+
+	return item
+}
+
+// Matches a fill-in response. Case doesn't matter and order matters unless the pattern says otherwise.
+func matchFillIn(pattern string, response string) bool {
+	pattern, flags := parsePatternFlags(pattern, patternFlagSet{orderMatters: true})
+
+	expected := splitResponse(pattern)
+	actual := splitResponse(response)
+
+	for _, items := range [][]string{expected, actual} {
+		for i := range items {
+			items[i] = stripLang(items[i])
+
+			if !flags.caseMatters {
+				items[i] = strings.ToLower(items[i])
+			}
+		}
+	}
+
+	return sameItems(expected, actual, flags.orderMatters)
+}
+
+// Matches a numeric response against an exact value or an inclusive range with optional bounds
+func matchNumeric(pattern string, response string) (bool, error) {
+	v, err := strconv.ParseFloat(response, 64)
+
+	if err != nil {
+		return false, nil
+	}
+
+	return inRange(pattern, v)
+}
+
+// Checks whether a value is within a numeric range pattern
+func inRange(pattern string, v float64) (bool, error) {
+	bounds := strings.SplitN(pattern, RangeDelimiter, 2)
+
+	if len(bounds) == 1 {
+		exact, err := strconv.ParseFloat(pattern, 64)
+
+		if err != nil {
+			return false, fmt.Errorf("invalid numeric pattern %q: %w", pattern, err)
+		}
+
+		return v == exact, nil
+	}
+
+	if bounds[0] != "" {
+		min, err := strconv.ParseFloat(bounds[0], 64)
+
+		if err != nil {
+			return false, fmt.Errorf("invalid numeric pattern %q: %w", pattern, err)
+		}
+
+		if v < min {
+			return false, nil
+		}
+	}
+
+	if bounds[1] != "" {
+		max, err := strconv.ParseFloat(bounds[1], 64)
+
+		if err != nil {
+			return false, fmt.Errorf("invalid numeric pattern %q: %w", pattern, err)
+		}
+
+		if v > max {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Matches a performance response. Each step's response is compared exactly, or numerically when the pattern is a range.
+func matchPerformance(pattern string, response string) (bool, error) {
+	pattern, flags := parsePatternFlags(pattern, patternFlagSet{orderMatters: true})
+
+	expected := splitResponse(pattern)
+	actual := splitResponse(response)
+
+	if len(expected) != len(actual) {
+		return false, nil
+	}
+
+	if flags.orderMatters {
+		for i := range expected {
+			ok, err := matchStep(expected[i], actual[i])
+
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	used := make([]bool, len(actual))
+
+	for _, e := range expected {
+		found := false
+
+		for i, a := range actual {
+			if used[i] {
+				continue
+			}
+
+			ok, err := matchStep(e, a)
+
+			if err != nil {
+				return false, err
+			}
+
+			if ok {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Matches a single performance step
+func matchStep(expected string, actual string) (bool, error) {
+	e := strings.SplitN(expected, PairDelimiter, 2)
+	a := strings.SplitN(actual, PairDelimiter, 2)
+
+	if len(e) != 2 {
+		return false, fmt.Errorf("invalid performance pattern %q", expected)
+	}
+
+	if len(a) != 2 || e[0] != a[0] {
+		return false, nil
+	}
+
+	if strings.Contains(e[1], RangeDelimiter) {
+		v, err := strconv.ParseFloat(a[1], 64)
+
+		if err != nil {
+			return false, nil
+		}
+
+		return inRange(e[1], v)
+	}
+
+	return e[1] == a[1], nil
+}
@@ -0,0 +1,265 @@
+package statement
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	// Register the hash functions used by the supported algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// JWS algorithm used to sign a statement
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#signature-requirements
+type SigningAlgorithm string
+
+const (
+	RS256 SigningAlgorithm = "RS256"
+	RS384 SigningAlgorithm = "RS384"
+	RS512 SigningAlgorithm = "RS512"
+)
+
+var (
+	ErrNotSigned          = errors.New("statement is not signed")
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrSignatureMismatch  = errors.New("signed statement doesn't match the statement")
+	ErrUnsupportedAlg     = errors.New("unsupported signing algorithm")
+	ErrMissingCertificate = errors.New("signature has no certificate and no public key was provided")
+)
+
+func (alg SigningAlgorithm) hash() (crypto.Hash, error) {
+	switch alg {
+	case RS256:
+		return crypto.SHA256, nil
+	case RS384:
+		return crypto.SHA384, nil
+	case RS512:
+		return crypto.SHA512, nil
+	}
+
+	return 0, ErrUnsupportedAlg
+}
+
+type jwsHeader struct {
+	Alg SigningAlgorithm `json:"alg"`
+	X5c []string         `json:"x5c,omitempty"`
+}
+
+// SignStatement signs the statement and appends the signature attachment to it.
+// The certificate chain x5c, leaf first, is embedded in the signature when provided. RS256 is used unless another algorithm is given.
+func SignStatement(stmt *Statement, key *rsa.PrivateKey, x5c []*x509.Certificate, alg ...SigningAlgorithm) error {
+	if stmt == nil {
+		return errors.New("statement can't be nil")
+	}
+
+	if key == nil {
+		return errors.New("key can't be nil")
+	}
+
+	header := jwsHeader{Alg: RS256}
+
+	if len(alg) > 0 {
+		header.Alg = alg[0]
+	}
+
+	h, err := header.Alg.hash()
+
+	if err != nil {
+		return err
+	}
+
+	for _, cert := range x5c {
+		header.X5c = append(header.X5c, base64.StdEncoding.EncodeToString(cert.Raw))
+	}
+
+	hb, err := json.Marshal(header)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal header: %w", err)
+	}
+
+	pb, err := json.Marshal(stmt)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal statement: %w", err)
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(hb) + "." + base64.RawURLEncoding.EncodeToString(pb)
+
+	hasher := h.New()
+	hasher.Write([]byte(input))
+
+	sig, err := rsa.SignPKCS1v15(nil, key, h, hasher.Sum(nil))
+
+	if err != nil {
+		return fmt.Errorf("failed to sign: %w", err)
+	}
+
+	jws := input + "." + base64.RawURLEncoding.EncodeToString(sig)
+
+	attachment := NewAttachmentWithContent(AttachmentUsageSignature, LanguageMap{"en-US": "Signature"}, "application/octet-stream", []byte(jws))
+	stmt.Attachments = append(stmt.Attachments, *attachment)
+
+	return nil
+}
+
+// VerifyStatement optional parameters
+type VerifyOptions struct {
+	// Trusted roots used to validate the certificate chain. System roots are used if nil.
+	Roots *x509.CertPool
+	// Key used when the signature carries no certificate
+	PublicKey *rsa.PublicKey
+}
+
+// VerifyStatement validates the signature attachment of a statement. The signature content must have been
+// retrieved along with the statement. The certificate chain embedded in the signature is validated against the
+// given roots and the signed payload must match the statement.
+func VerifyStatement(stmt *Statement, params ...*VerifyOptions) error {
+	var opt VerifyOptions
+
+	if len(params) > 0 && params[0] != nil {
+		opt = *params[0]
+	}
+
+	if stmt == nil {
+		return errors.New("statement can't be nil")
+	}
+
+	var jws []byte
+
+	for _, a := range stmt.Attachments {
+		if a.UsageType == AttachmentUsageSignature {
+			jws = a.Content
+			break
+		}
+	}
+
+	if len(jws) == 0 {
+		return ErrNotSigned
+	}
+
+	parts := strings.Split(string(jws), ".")
+
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: malformed jws", ErrInvalidSignature)
+	}
+
+	hb, err := base64.RawURLEncoding.DecodeString(parts[0])
+
+	if err != nil {
+		return fmt.Errorf("%w: malformed header: %s", ErrInvalidSignature, err)
+	}
+
+	header := jwsHeader{}
+
+	if err := json.Unmarshal(hb, &header); err != nil {
+		return fmt.Errorf("%w: malformed header: %s", ErrInvalidSignature, err)
+	}
+
+	h, err := header.Alg.hash()
+
+	if err != nil {
+		return err
+	}
+
+	key := opt.PublicKey
+
+	if len(header.X5c) > 0 {
+		certs := make([]*x509.Certificate, len(header.X5c))
+
+		for i, c := range header.X5c {
+			der, err := base64.StdEncoding.DecodeString(c)
+
+			if err != nil {
+				return fmt.Errorf("%w: malformed certificate: %s", ErrInvalidSignature, err)
+			}
+
+			if certs[i], err = x509.ParseCertificate(der); err != nil {
+				return fmt.Errorf("%w: malformed certificate: %s", ErrInvalidSignature, err)
+			}
+		}
+
+		intermediates := x509.NewCertPool()
+
+		for _, c := range certs[1:] {
+			intermediates.AddCert(c)
+		}
+
+		_, err := certs[0].Verify(x509.VerifyOptions{
+			Roots:         opt.Roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+		}
+
+		rsaKey, ok := certs[0].PublicKey.(*rsa.PublicKey)
+
+		if !ok {
+			return fmt.Errorf("%w: certificate key is not an rsa key", ErrInvalidSignature)
+		}
+
+		key = rsaKey
+	}
+
+	if key == nil {
+		return ErrMissingCertificate
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature: %s", ErrInvalidSignature, err)
+	}
+
+	hasher := h.New()
+	hasher.Write([]byte(parts[0] + "." + parts[1]))
+
+	if err := rsa.VerifyPKCS1v15(key, h, hasher.Sum(nil), sig); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	pb, err := base64.RawURLEncoding.DecodeString(parts[1])
+
+	if err != nil {
+		return fmt.Errorf("%w: malformed payload: %s", ErrInvalidSignature, err)
+	}
+
+	signed := Statement{}
+
+	if err := json.Unmarshal(pb, &signed); err != nil {
+		return fmt.Errorf("%w: malformed payload: %s", ErrInvalidSignature, err)
+	}
+
+	received := *stmt
+	received.Attachments = nil
+
+	for _, a := range stmt.Attachments {
+		if a.UsageType != AttachmentUsageSignature {
+			received.Attachments = append(received.Attachments, a)
+		}
+	}
+
+	// The LRS assigns an id and a timestamp if the signed statement has none
+	if signed.ID == nil {
+		received.ID = nil
+	}
+
+	if signed.Timestamp == nil {
+		received.Timestamp = nil
+	}
+
+	if equal, _ := Equivalent(signed, received); !equal {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
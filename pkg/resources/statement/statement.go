@@ -2,7 +2,6 @@ package statement
 
 import (
 	"encoding/json"
-	"time"
 )
 
 // Statement represents an evidence for any sort of experience or event which is to be tracked in xAPI.
@@ -19,8 +18,8 @@ type StatementOptions struct {
 	ID          *string      `json:"id,omitempty" xapi:"recommended"`
 	Result      *Result      `json:"result,omitempty"  xapi:"optional"`
 	Context     *Context     `json:"context,omitempty"  xapi:"optional"`
-	Timestamp   *time.Time   `json:"timestamp,omitempty"  xapi:"optional"`
-	Stored      *time.Time   `json:"stored,omitempty"  xapi:"optional"`
+	Timestamp   *Timestamp   `json:"timestamp,omitempty"  xapi:"optional"`
+	Stored      *Timestamp   `json:"stored,omitempty"  xapi:"optional"`
 	Authority   IActor       `json:"authority,omitempty" xapi:"optional"`
 	Version     *string      `json:"version,omitempty" xapi:"optional"`
 	Attachments []Attachment `json:"attachments,omitempty" xapi:"optional"`
@@ -55,6 +54,17 @@ type StatementResult struct {
 	Statements []Statement `json:"statements"`
 }
 
+// Marshals the statement, setting the object type of an agent object
+func (s Statement) MarshalJSON() ([]byte, error) {
+	// The local type has no methods, so marshalling it doesn't recurse
+	type statement Statement
+
+	stmt := statement(s)
+	stmt.Object = withObjectType(s.Object)
+
+	return json.Marshal(stmt)
+}
+
 // Unmarshals the statement. A custom unmarshaller is required due to Actor, Object and Authority fields being interfaces.
 func (s *Statement) UnmarshalJSON(data []byte) error {
 	raw := struct {
@@ -64,8 +74,8 @@ func (s *Statement) UnmarshalJSON(data []byte) error {
 		ID          *string         `json:"id,omitempty"`
 		Result      *Result         `json:"result,omitempty"`
 		Context     *Context        `json:"context,omitempty"`
-		Timestamp   *time.Time      `json:"timestamp,omitempty"`
-		Stored      *time.Time      `json:"stored,omitempty"`
+		Timestamp   *Timestamp      `json:"timestamp,omitempty"`
+		Stored      *Timestamp      `json:"stored,omitempty"`
 		Authority   json.RawMessage `json:"authority,omitempty"`
 		Version     *string         `json:"version,omitempty"`
 		Attachments []Attachment    `json:"attachments,omitempty"`
@@ -93,8 +103,11 @@ func (s *Statement) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if err = UnmarshalActor(raw.Authority, &s.Authority); err != nil {
-		return err
+	// Statements which were not stored by an LRS yet (e.g. signed payloads) don't have an authority
+	if len(raw.Authority) > 0 && string(raw.Authority) != "null" {
+		if err = UnmarshalActor(raw.Authority, &s.Authority); err != nil {
+			return err
+		}
 	}
 
 	return nil
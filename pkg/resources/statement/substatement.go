@@ -1,8 +1,6 @@
 package statement
 
-import (
-	"time"
-)
+import "encoding/json"
 
 // A SubStatement is like a StatementRef in that it is included as part of a containing Statement, but unlike a StatementRef,
 // it does not represent an event that has occurred. It can be used to describe, for example, a predication of a potential future
@@ -18,11 +16,35 @@ func (s SubStatement) GetObjectType() string {
 	return "SubStatement"
 }
 
+// Marshals the substatement. Without it the marshaller of the embedded statement would be used, leaving ObjectType out.
+func (s SubStatement) MarshalJSON() ([]byte, error) {
+	type statement Statement
+
+	stmt := statement(s.Statement)
+	stmt.Object = withObjectType(s.Statement.Object)
+
+	return json.Marshal(struct {
+		statement
+		ObjectType string `json:"objectType"`
+	}{stmt, s.ObjectType})
+}
+
+// Unmarshals the substatement. Without it the unmarshaller of the embedded statement would be used, leaving ObjectType unset.
+func (s *SubStatement) UnmarshalJSON(data []byte) error {
+	if err := s.Statement.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	s.ObjectType = "SubStatement"
+
+	return nil
+}
+
 // SubStatement optional parameters
 type SubStatementOptions struct {
 	Result      *Result      `json:"result,omitempty"  xapi:"optional"`
 	Context     *Context     `json:"context,omitempty"  xapi:"optional"`
-	Timestamp   *time.Time   `json:"timestamp,omitempty"  xapi:"optional"`
+	Timestamp   *Timestamp   `json:"timestamp,omitempty"  xapi:"optional"`
 	Attachments []Attachment `json:"attachments,omitempty" xapi:"optional"`
 }
 
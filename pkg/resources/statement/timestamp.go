@@ -0,0 +1,76 @@
+package statement
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ISO 8601 layout with millisecond precision and a mandatory time zone
+const TimestampFormat = "2006-01-02T15:04:05.000Z07:00"
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999Z07",
+}
+
+// Timestamp is a point in time formatted as ISO 8601 with a time zone. Parsed timestamps keep the form they were
+// written in, with its precision and time zone, and are marshaled back unchanged. Others are marshaled with
+// millisecond precision, as LRSs store them.
+// https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#timestamps
+type Timestamp struct {
+	time.Time
+
+	// Form the timestamp was parsed from, empty when created from a time
+	lexical string
+}
+
+// NewTimestamp creates a timestamp truncated to milliseconds so it compares equal to the value an LRS returns
+func NewTimestamp(t time.Time) *Timestamp {
+	return &Timestamp{Time: t.Truncate(time.Millisecond)}
+}
+
+// ParseTimestamp parses an ISO 8601 timestamp. A time zone is required.
+func ParseTimestamp(s string) (Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Timestamp{Time: t, lexical: s}, nil
+		}
+	}
+
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+// String formats the timestamp as ISO 8601, in the form it was parsed from if any
+func (t Timestamp) String() string {
+	if len(t.lexical) > 0 {
+		return t.lexical
+	}
+
+	return t.Format(TimestampFormat)
+}
+
+// MarshalJSON marshals the timestamp as an ISO 8601 string
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON unmarshals an ISO 8601 timestamp
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	v, err := ParseTimestamp(s)
+
+	if err != nil {
+		return err
+	}
+
+	*t = v
+
+	return nil
+}
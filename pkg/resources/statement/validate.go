@@ -0,0 +1,240 @@
+package statement
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var sha1Pattern = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// Validate checks the statement holds the required properties with well formed identifiers, and validates its
+// result, context and activity definitions against the given registry, or the default registry.
+// It returns ValidationErrors describing every problem found.
+func (s Statement) Validate(registry ...*ExtensionRegistry) error {
+	var errs ValidationErrors
+
+	if s.ID != nil && !uuidPattern.MatchString(*s.ID) {
+		errs = append(errs, ValidationError{Path: "id", Message: "not a UUID"})
+	}
+
+	errs = append(errs, s.validate("", registry)...)
+
+	if s.Authority != nil {
+		errs = append(errs, validateActor(s.Authority, "authority")...)
+	}
+
+	if s.Verb.ID == VerbVoided {
+		switch s.Object.(type) {
+		case *StatementRef, StatementRef:
+		default:
+			errs = append(errs, ValidationError{Path: "object", Message: "voiding statements must target a StatementRef"})
+		}
+	}
+
+	return errs.err()
+}
+
+// Validates the properties shared with sub-statements
+func (s Statement) validate(path string, registry []*ExtensionRegistry) ValidationErrors {
+	var errs ValidationErrors
+
+	if s.Actor == nil {
+		errs = append(errs, ValidationError{Path: path + "actor", Message: "missing"})
+	} else {
+		errs = append(errs, validateActor(s.Actor, path+"actor")...)
+	}
+
+	if len(s.Verb.ID) == 0 {
+		errs = append(errs, ValidationError{Path: path + "verb.id", Message: "missing"})
+	} else if !IsIRI(s.Verb.ID) {
+		errs = append(errs, ValidationError{Path: path + "verb.id", Message: "not an IRI"})
+	}
+
+	if s.Verb.Display != nil {
+		if err := s.Verb.Display.Validate(); err != nil {
+			errs = append(errs, ValidationError{Path: path + "verb.display", Message: err.Error()})
+		}
+	}
+
+	errs = append(errs, validateObject(s.Object, path+"object", registry)...)
+
+	if s.Result != nil {
+		errs = append(errs, prefixErrors(path+"result", s.Result.Validate(registry...))...)
+	}
+
+	if s.Context != nil {
+		errs = append(errs, prefixErrors(path+"context", s.Context.Validate(registry...))...)
+
+		if s.Context.Registration != nil && !uuidPattern.MatchString(*s.Context.Registration) {
+			errs = append(errs, ValidationError{Path: path + "context.registration", Message: "not a UUID"})
+		}
+	}
+
+	for i, a := range s.Attachments {
+		errs = append(errs, a.validate(path+"attachments["+strconv.Itoa(i)+"]")...)
+	}
+
+	return errs
+}
+
+// Checks the required properties of an attachment
+func (a Attachment) validate(path string) ValidationErrors {
+	var errs ValidationErrors
+
+	if !IsIRI(a.UsageType) {
+		errs = append(errs, ValidationError{Path: path + ".usageType", Message: "not an IRI"})
+	}
+
+	if len(a.Display) == 0 {
+		errs = append(errs, ValidationError{Path: path + ".display", Message: "missing"})
+	} else if err := a.Display.Validate(); err != nil {
+		errs = append(errs, ValidationError{Path: path + ".display", Message: err.Error()})
+	}
+
+	if len(a.ContentType) == 0 {
+		errs = append(errs, ValidationError{Path: path + ".contentType", Message: "missing"})
+	}
+
+	if a.Length < 0 {
+		errs = append(errs, ValidationError{Path: path + ".length", Message: "negative"})
+	}
+
+	if len(a.SHA2) == 0 {
+		errs = append(errs, ValidationError{Path: path + ".sha2", Message: "missing"})
+	}
+
+	return errs
+}
+
+// Checks the format of the IFI properties, which IFI() doesn't check
+func validateIdentity(mbox *string, mboxSHA1Sum *string, account *Account, path string) ValidationErrors {
+	var errs ValidationErrors
+
+	if mbox != nil && !strings.HasPrefix(strings.ToLower(*mbox), "mailto:") {
+		errs = append(errs, ValidationError{Path: path + ".mbox", Message: "missing the mailto: scheme"})
+	}
+
+	if mboxSHA1Sum != nil && !sha1Pattern.MatchString(*mboxSHA1Sum) {
+		errs = append(errs, ValidationError{Path: path + ".mbox_sha1sum", Message: "not a hex encoded SHA1"})
+	}
+
+	if account != nil {
+		if !IsIRI(account.HomePage) {
+			errs = append(errs, ValidationError{Path: path + ".account.homePage", Message: "not an IRL"})
+		}
+
+		if len(account.Name) == 0 {
+			errs = append(errs, ValidationError{Path: path + ".account.name", Message: "missing"})
+		}
+	}
+
+	return errs
+}
+
+// Checks an agent or group has a single valid IFI, anonymous groups are checked to have identified members
+func validateActor(actor IActor, path string) ValidationErrors {
+	var errs ValidationErrors
+
+	switch v := actor.(type) {
+	case Agent:
+		return validateActor(&v, path)
+	case Group:
+		return validateActor(&v, path)
+	case *Agent:
+		if _, err := v.IFI(); err != nil {
+			errs = append(errs, ValidationError{Path: path, Message: err.Error()})
+		}
+
+		errs = append(errs, validateIdentity(v.Mbox, v.MboxSHA1Sum, v.Account, path)...)
+	case *Group:
+		if v.IsIdentified() {
+			if _, err := v.IFI(); err != nil {
+				errs = append(errs, ValidationError{Path: path, Message: err.Error()})
+			}
+
+			errs = append(errs, validateIdentity(v.Mbox, v.MboxSHA1Sum, v.Account, path)...)
+		} else if len(v.Members) == 0 {
+			errs = append(errs, ValidationError{Path: path + ".members", Message: "anonymous groups must have members"})
+		}
+
+		for i, m := range v.Members {
+			errs = append(errs, validateActor(&m, path+".members["+strconv.Itoa(i)+"]")...)
+		}
+	}
+
+	return errs
+}
+
+func validateObject(object IObject, path string, registry []*ExtensionRegistry) ValidationErrors {
+	var errs ValidationErrors
+
+	switch v := object.(type) {
+	case Activity:
+		return validateObject(&v, path, registry)
+	case StatementRef:
+		return validateObject(&v, path, registry)
+	case SubStatement:
+		return validateObject(&v, path, registry)
+	case nil:
+		errs = append(errs, ValidationError{Path: path, Message: "missing"})
+	case *Activity:
+		if !IsIRI(v.ID) {
+			errs = append(errs, ValidationError{Path: path + ".id", Message: "not an IRI"})
+		}
+
+		if v.Definition != nil {
+			errs = append(errs, prefixErrors(path+".definition", v.Definition.Validate(registry...))...)
+		}
+	case *StatementRef:
+		if !uuidPattern.MatchString(v.ID) {
+			errs = append(errs, ValidationError{Path: path + ".id", Message: "not a UUID"})
+		}
+	case *SubStatement:
+		errs = append(errs, v.Statement.validate(path+".", registry)...)
+
+		if v.ID != nil || v.Stored != nil || v.Version != nil || v.Authority != nil {
+			errs = append(errs, ValidationError{Path: path, Message: "sub-statements can't have an id, stored, version or authority"})
+		}
+
+		switch v.Object.(type) {
+		case *SubStatement, SubStatement:
+			errs = append(errs, ValidationError{Path: path + ".object", Message: "sub-statements can't be nested"})
+		}
+	case IActor:
+		errs = append(errs, validateActor(v, path)...)
+	}
+
+	return errs
+}
+
+// Prefixes the paths of validation errors, wrapping other errors in a validation error of the path itself
+func prefixErrors(path string, err error) ValidationErrors {
+	if err == nil {
+		return nil
+	}
+
+	var errs ValidationErrors
+
+	if !errors.As(err, &errs) {
+		return ValidationErrors{{Path: path, Message: err.Error()}}
+	}
+
+	prefixed := make(ValidationErrors, len(errs))
+
+	for i, e := range errs {
+		prefixed[i] = ValidationError{Path: path + "." + e.Path, Message: e.Message}
+	}
+
+	return prefixed
+}
+
+// IsIRI reports whether a value is an absolute IRI
+func IsIRI(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.IsAbs()
+}
@@ -4,9 +4,12 @@ package statement
 // https://github.com/adlnet/xAPI-Spec/blob/master/xAPI-Data.md#243-verb
 type Verb struct {
 	ID      string      `json:"id" xapi:"required"`
-	Display LanguageMap `json:"display" xapi:"required"`
+	Display LanguageMap `json:"display,omitempty" xapi:"required"`
 }
 
+// IRI of the verb voiding statements
+const VerbVoided = "http://adlnet.gov/expapi/verbs/voided"
+
 // Creates a new verb
 func NewVerb(id string, display LanguageMap) *Verb {
 	return &Verb{
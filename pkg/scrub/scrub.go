@@ -0,0 +1,316 @@
+package scrub
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+)
+
+// Options of a scrubber
+type Options struct {
+	// Removes the names of agents and groups
+	DropNames bool
+	// Key of the HMAC used to pseudonymise mailboxes and OpenIDs. When set, mbox and mbox_sha1sum are replaced by an
+	// mbox_sha1sum holding the HMAC-SHA1 of the mailbox's SHA1, so both forms of a mailbox map to the same value,
+	// and openid by a urn:hmac-sha1: URN holding the HMAC-SHA1 of the OpenID.
+	MboxKey []byte
+	// Replaces account names found in the table
+	AccountNames map[string]string
+	// Replaces account names missing from AccountNames by their HMAC, requires MboxKey
+	HashUnmappedAccounts bool
+	// IRIs of the extensions removed from results, contexts and activity definitions
+	RedactExtensions []string
+}
+
+// Scrubber strips or pseudonymises personal data in statements
+type Scrubber struct {
+	Options Options
+	redact  map[string]bool
+}
+
+// NewScrubber creates a new scrubber
+func NewScrubber(params ...*Options) *Scrubber {
+	s := Scrubber{redact: make(map[string]bool)}
+
+	if len(params) > 0 && params[0] != nil {
+		s.Options = *params[0]
+	}
+
+	for _, iri := range s.Options.RedactExtensions {
+		s.redact[iri] = true
+	}
+
+	return &s
+}
+
+// Scrub returns a copy of the statement with the policies applied to the actor, authority, object, context and result.
+// The original statement isn't modified.
+func (s *Scrubber) Scrub(stmt statement.Statement) (*statement.Statement, error) {
+	if s.Options.HashUnmappedAccounts && len(s.Options.MboxKey) == 0 {
+		return nil, errors.New("hashing unmapped accounts requires a key")
+	}
+
+	scrubbed := stmt
+
+	var err error
+
+	scrubbed.Actor, err = s.actor(stmt.Actor)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrub actor: %w", err)
+	}
+
+	scrubbed.Authority, err = s.actor(stmt.Authority)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrub authority: %w", err)
+	}
+
+	scrubbed.Object, err = s.object(stmt.Object)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrub object: %w", err)
+	}
+
+	scrubbed.Context, err = s.context(stmt.Context)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrub context: %w", err)
+	}
+
+	scrubbed.Result = s.result(stmt.Result)
+
+	return &scrubbed, nil
+}
+
+// Returns the hex encoded HMAC-SHA1 of a value
+func (s *Scrubber) hmac(value string) string {
+	mac := hmac.New(sha1.New, s.Options.MboxKey)
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Scrubber) agent(a statement.Agent) (*statement.Agent, error) {
+	if s.Options.DropNames {
+		a.Name = nil
+	}
+
+	if len(s.Options.MboxKey) > 0 {
+		if a.Mbox != nil {
+			sum, err := statement.MboxSHA1Sum(*a.Mbox)
+
+			if err != nil {
+				return nil, err
+			}
+
+			a.Mbox = nil
+			a.MboxSHA1Sum = &sum
+		}
+
+		if a.MboxSHA1Sum != nil {
+			sum := s.hmac(strings.ToLower(*a.MboxSHA1Sum))
+			a.MboxSHA1Sum = &sum
+		}
+
+		if a.OpenID != nil {
+			openID := "urn:hmac-sha1:" + s.hmac(*a.OpenID)
+			a.OpenID = &openID
+		}
+	}
+
+	if a.Account != nil {
+		account := *a.Account
+
+		if name, ok := s.Options.AccountNames[account.Name]; ok {
+			account.Name = name
+		} else if s.Options.HashUnmappedAccounts {
+			account.Name = s.hmac(account.HomePage + "|" + account.Name)
+		}
+
+		a.Account = &account
+	}
+
+	return &a, nil
+}
+
+func (s *Scrubber) group(g statement.Group) (*statement.Group, error) {
+	// Identified groups get the same treatment as agents
+	identity, err := s.agent(statement.Agent{Name: g.Name, Mbox: g.Mbox, MboxSHA1Sum: g.MboxSHA1Sum, OpenID: g.OpenID, Account: g.Account})
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.Name = identity.Name
+	g.Mbox = identity.Mbox
+	g.MboxSHA1Sum = identity.MboxSHA1Sum
+	g.OpenID = identity.OpenID
+	g.Account = identity.Account
+
+	if g.Members != nil {
+		members := make([]statement.Agent, len(g.Members))
+
+		for i, m := range g.Members {
+			member, err := s.agent(m)
+
+			if err != nil {
+				return nil, err
+			}
+
+			members[i] = *member
+		}
+
+		g.Members = members
+	}
+
+	return &g, nil
+}
+
+func (s *Scrubber) actor(actor statement.IActor) (statement.IActor, error) {
+	switch v := actor.(type) {
+	case *statement.Agent:
+		return s.agent(*v)
+	case statement.Agent:
+		return s.agent(v)
+	case *statement.Group:
+		return s.group(*v)
+	case statement.Group:
+		return s.group(v)
+	}
+
+	return actor, nil
+}
+
+func (s *Scrubber) object(object statement.IObject) (statement.IObject, error) {
+	switch v := object.(type) {
+	case *statement.Agent, statement.Agent, *statement.Group, statement.Group:
+		actor, err := s.actor(v.(statement.IActor))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return actor.(statement.IObject), nil
+	case *statement.Activity:
+		return s.activity(*v), nil
+	case statement.Activity:
+		return s.activity(v), nil
+	case *statement.SubStatement:
+		return s.subStatement(*v)
+	case statement.SubStatement:
+		return s.subStatement(v)
+	}
+
+	return object, nil
+}
+
+func (s *Scrubber) subStatement(sub statement.SubStatement) (*statement.SubStatement, error) {
+	scrubbed, err := s.Scrub(sub.Statement)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sub.Statement = *scrubbed
+
+	return &sub, nil
+}
+
+func (s *Scrubber) activity(a statement.Activity) *statement.Activity {
+	if a.Definition != nil && a.Definition.Extensions != nil {
+		definition := *a.Definition
+		definition.Extensions = s.extensions(definition.Extensions)
+		a.Definition = &definition
+	}
+
+	return &a
+}
+
+func (s *Scrubber) activities(activities []statement.Activity) []statement.Activity {
+	if activities == nil {
+		return nil
+	}
+
+	scrubbed := make([]statement.Activity, len(activities))
+
+	for i, a := range activities {
+		scrubbed[i] = *s.activity(a)
+	}
+
+	return scrubbed
+}
+
+func (s *Scrubber) context(ctx *statement.Context) (*statement.Context, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+
+	scrubbed := *ctx
+
+	var err error
+
+	scrubbed.Instructor, err = s.actor(ctx.Instructor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Team != nil {
+		scrubbed.Team, err = s.group(*ctx.Team)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if ctx.ContextActivities != nil {
+		scrubbed.ContextActivities = &statement.ContextActivities{
+			Parent:   s.activities(ctx.ContextActivities.Parent),
+			Grouping: s.activities(ctx.ContextActivities.Grouping),
+			Category: s.activities(ctx.ContextActivities.Category),
+			Other:    s.activities(ctx.ContextActivities.Other),
+		}
+	}
+
+	scrubbed.Extensions = s.extensions(ctx.Extensions)
+
+	return &scrubbed, nil
+}
+
+func (s *Scrubber) result(result *statement.Result) *statement.Result {
+	if result == nil {
+		return nil
+	}
+
+	scrubbed := *result
+	scrubbed.Extensions = s.extensions(result.Extensions)
+
+	return &scrubbed
+}
+
+// Returns a copy of the extensions without the redacted keys, or nil if none remain
+func (s *Scrubber) extensions(ext *statement.Extensions) *statement.Extensions {
+	if ext == nil || len(s.redact) == 0 {
+		return ext
+	}
+
+	scrubbed := statement.Extensions{}
+
+	for k, v := range *ext {
+		if !s.redact[k] {
+			scrubbed[k] = v
+		}
+	}
+
+	if len(scrubbed) == 0 {
+		return nil
+	}
+
+	return &scrubbed
+}
@@ -0,0 +1,217 @@
+package tail
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+)
+
+// Header holding the time before which the LRS guarantees every stored statement is returned by queries
+const consistentThroughHeader = "X-Experience-API-Consistent-Through"
+
+// Options of a tail
+type Options struct {
+	// Filters of the polls, since and ascending are overridden
+	Query client.StatementQueryParams
+	// Only statements stored after this time are handled. When nil, tailing starts after the latest statement
+	// matching the filters.
+	Since *time.Time
+	// Time between polls, defaults to 2 seconds
+	Interval time.Duration
+	// Receives failed polls, discarded if nil
+	Logger *log.Logger
+}
+
+// Tailer polls an LRS for the statements stored since the previous poll
+type Tailer struct {
+	LRS     *client.RemoteLRS
+	Options Options
+	started bool
+	// Exclusive lower bound of the stored time of the next poll
+	since time.Time
+	// Stored times of the statements already handled, keyed by id, which the next poll may return again
+	seen map[string]time.Time
+}
+
+// NewTailer creates a new tailer
+func NewTailer(lrs *client.RemoteLRS, params ...*Options) *Tailer {
+	t := Tailer{
+		LRS:  lrs,
+		seen: make(map[string]time.Time),
+	}
+
+	if len(params) > 0 && params[0] != nil {
+		t.Options = *params[0]
+	}
+
+	if t.Options.Interval <= 0 {
+		t.Options.Interval = 2 * time.Second
+	}
+
+	if t.Options.Logger == nil {
+		t.Options.Logger = log.New(io.Discard, "", 0)
+	}
+
+	return &t
+}
+
+// Run polls the LRS until ctx is done, calling handle with every new statement in stored order. Failed polls are
+// logged and retried on the next one, an error returned by handle stops the tail and is returned.
+func (t *Tailer) Run(ctx context.Context, handle func(statement.Statement) error) error {
+	for {
+		var handleErr error
+
+		err := t.Poll(func(stmt statement.Statement) error {
+			handleErr = handle(stmt)
+			return handleErr
+		})
+
+		if handleErr != nil {
+			return handleErr
+		}
+
+		if err != nil {
+			t.Options.Logger.Printf("poll failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(t.Options.Interval):
+		}
+	}
+}
+
+// Poll fetches every page of the statements stored since the previous poll, calling handle with the ones not seen yet
+func (t *Tailer) Poll(handle func(statement.Statement) error) error {
+	if !t.started {
+		if err := t.start(); err != nil {
+			return err
+		}
+
+		t.started = true
+	}
+
+	params := t.Options.Query
+	params.Since = utils.Ptr(t.since)
+	params.Ascending = utils.Ptr(true)
+
+	latest := t.since
+	var consistent *time.Time
+
+	result, resp, err := t.LRS.QueryStatements(&params)
+
+	if err == nil && resp.Status == 200 {
+		consistent = consistentThrough(resp)
+	}
+
+	for {
+		if err != nil {
+			return fmt.Errorf("failed to query statements: %w", err)
+		}
+
+		if resp.Status != 200 {
+			return fmt.Errorf("failed to query statements: %w", resp.Err())
+		}
+
+		for _, stmt := range result.Statements {
+			if stmt.ID == nil || stmt.Stored == nil {
+				continue
+			}
+
+			if stmt.Stored.Time.After(latest) {
+				latest = stmt.Stored.Time
+			}
+
+			if _, ok := t.seen[*stmt.ID]; ok {
+				continue
+			}
+
+			if err := handle(stmt); err != nil {
+				return err
+			}
+
+			t.seen[*stmt.ID] = stmt.Stored.Time
+		}
+
+		if len(result.More) == 0 {
+			break
+		}
+
+		result, resp, err = t.LRS.MoreStatements(result.More)
+	}
+
+	// Statements stored after the consistent through time may still show up with an older stored time. since is
+	// exclusive, so the next poll starts right before the consistent through time and seen statements are skipped.
+	if consistent != nil && consistent.Before(latest) {
+		latest = consistent.Add(-time.Millisecond)
+	}
+
+	// Queries only send milliseconds, pruning against a finer time would drop statements the next poll returns
+	latest = latest.Truncate(time.Millisecond)
+
+	if latest.After(t.since) {
+		t.since = latest
+	}
+
+	for id, stored := range t.seen {
+		if !stored.After(t.since) {
+			delete(t.seen, id)
+		}
+	}
+
+	return nil
+}
+
+// Sets the time tailing starts from
+func (t *Tailer) start() error {
+	if t.Options.Since != nil {
+		t.since = t.Options.Since.Truncate(time.Millisecond)
+		return nil
+	}
+
+	params := t.Options.Query
+	params.Since = nil
+	params.Ascending = nil
+	params.Limit = utils.Ptr(int64(1))
+
+	result, resp, err := t.LRS.QueryStatements(&params)
+
+	if err != nil {
+		return fmt.Errorf("failed to query latest statement: %w", err)
+	}
+
+	if resp.Status != 200 {
+		return fmt.Errorf("failed to query latest statement: %w", resp.Err())
+	}
+
+	switch {
+	case len(result.Statements) > 0 && result.Statements[0].Stored != nil:
+		t.since = result.Statements[0].Stored.Time
+	case consistentThrough(resp) != nil:
+		t.since = *consistentThrough(resp)
+	default:
+		t.since = time.Now()
+	}
+
+	t.since = t.since.Truncate(time.Millisecond)
+
+	return nil
+}
+
+// Returns the consistent through time of a response, or nil if it is missing or invalid
+func consistentThrough(resp *client.Response) *time.Time {
+	ts, err := statement.ParseTimestamp(resp.Response.Header.Get(consistentThroughHeader))
+
+	if err != nil {
+		return nil
+	}
+
+	return &ts.Time
+}
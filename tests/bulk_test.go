@@ -0,0 +1,247 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/bulk"
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const bulkStatement = `{"actor": {"mbox": "mailto:learner%d@example.com"}, "verb": {"id": "%s"}, "object": {"id": "http://example.com/activity"}}`
+
+type BulkTestSuite struct {
+	suite.Suite
+	server  *httptest.Server
+	mu      sync.Mutex
+	stored  map[string]bool
+	batches int
+}
+
+func (suite *BulkTestSuite) SetupTest() {
+	suite.stored = make(map[string]bool)
+	suite.batches = 0
+
+	// Rejects statements with the rejected verb, along with the whole batch holding them
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+
+		b, _ := io.ReadAll(r.Body)
+
+		var statements []map[string]any
+
+		if r.Method == "POST" {
+			suite.batches++
+			json.Unmarshal(b, &statements)
+		} else {
+			statements = make([]map[string]any, 1)
+			json.Unmarshal(b, &statements[0])
+		}
+
+		for _, stmt := range statements {
+			if stmt["verb"].(map[string]any)["id"] == "http://example.com/rejected" {
+				w.WriteHeader(400)
+				fmt.Fprint(w, "rejected verb")
+				return
+			}
+
+			if suite.stored[stmt["id"].(string)] {
+				w.WriteHeader(409)
+				return
+			}
+		}
+
+		ids := []string{}
+
+		for _, stmt := range statements {
+			suite.stored[stmt["id"].(string)] = true
+			ids = append(ids, stmt["id"].(string))
+		}
+
+		if r.Method == "POST" {
+			json.NewEncoder(w).Encode(ids)
+		} else {
+			w.WriteHeader(204)
+		}
+	}))
+}
+
+func (suite *BulkTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *BulkTestSuite) lrs() *client.RemoteLRS {
+	lrs, err := client.NewRemoteLRS(suite.server.URL+"/", "1.0.3", "Basic dGVzdDp0ZXN0")
+	assert.Nil(suite.T(), err)
+
+	return lrs
+}
+
+func (suite *BulkTestSuite) input() string {
+	var lines []string
+
+	for i := 0; i < 10; i++ {
+		verb := "http://adlnet.gov/expapi/verbs/experienced"
+
+		if i == 6 {
+			verb = "http://example.com/rejected"
+		}
+
+		lines = append(lines, fmt.Sprintf(bulkStatement, i, verb))
+	}
+
+	lines[2] = "{not json"
+	lines[4] = fmt.Sprintf(bulkStatement, 4, "not an iri")
+
+	return strings.Join(lines, "\n") + "\n\n"
+}
+
+func (suite *BulkTestSuite) TestImport() {
+	var failures bytes.Buffer
+
+	progress := filepath.Join(suite.T().TempDir(), "progress.json")
+
+	stats, err := bulk.NewImporter(suite.lrs(), &bulk.ImportOptions{
+		BatchSize:    3,
+		Concurrency:  2,
+		ProgressFile: progress,
+		Failures:     &failures,
+	}).Run(strings.NewReader(suite.input()))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 7, stats.Statements)
+	assert.Equal(suite.T(), 3, stats.Failed)
+	assert.Equal(suite.T(), 7, len(suite.stored))
+
+	lines := map[int]string{}
+	scanner := bufio.NewScanner(&failures)
+
+	for scanner.Scan() {
+		f := bulk.Failure{}
+		assert.Nil(suite.T(), json.Unmarshal(scanner.Bytes(), &f))
+		lines[f.Line] = f.Error
+	}
+
+	assert.Len(suite.T(), lines, 3)
+	assert.Contains(suite.T(), lines[3], "invalid character")
+	assert.Equal(suite.T(), "verb.id: not an IRI", lines[5])
+	assert.Equal(suite.T(), "lrs responded with 400: rejected verb", lines[7])
+
+	saved, err := bulk.LoadProgress(progress)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), &bulk.Progress{BatchSize: 3, Done: 4}, saved)
+
+	// Resuming skips every processed batch
+	batches := suite.batches
+
+	stats, err = bulk.NewImporter(suite.lrs(), &bulk.ImportOptions{BatchSize: 3, ProgressFile: progress}).Run(strings.NewReader(suite.input()))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, stats.Statements)
+	assert.Equal(suite.T(), 10, stats.Skipped)
+	assert.Equal(suite.T(), batches, suite.batches)
+
+	_, err = bulk.NewImporter(suite.lrs(), &bulk.ImportOptions{BatchSize: 5, ProgressFile: progress}).Run(strings.NewReader(suite.input()))
+
+	assert.NotNil(suite.T(), err)
+
+	// Ids derived from the input make a second import without progress a no-op
+	stats, err = bulk.NewImporter(suite.lrs(), &bulk.ImportOptions{BatchSize: 3}).Run(strings.NewReader(suite.input()))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, stats.Statements)
+	assert.Equal(suite.T(), 7, stats.Conflicts)
+	assert.Equal(suite.T(), 7, len(suite.stored))
+}
+
+func (suite *BulkTestSuite) TestProgress() {
+	p := bulk.Progress{}
+
+	p.Complete(2)
+	p.Complete(0)
+
+	assert.True(suite.T(), p.Contains(0))
+	assert.False(suite.T(), p.Contains(1))
+	assert.True(suite.T(), p.Contains(2))
+	assert.Equal(suite.T(), 1, p.Done)
+
+	p.Complete(1)
+
+	assert.Equal(suite.T(), 3, p.Done)
+	assert.Empty(suite.T(), p.Completed)
+}
+
+func (suite *BulkTestSuite) TestExport() {
+	source := newSourceLRS(func(q url.Values) {
+		assert.Equal(suite.T(), "true", q.Get("ascending"))
+	})
+	defer source.Close()
+
+	lrs, err := client.NewRemoteLRS(source.URL+"/", "1.0.3", "Basic dGVzdDp0ZXN0")
+	assert.Nil(suite.T(), err)
+
+	var out bytes.Buffer
+
+	stats, err := bulk.Export(lrs, &out)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, stats.Statements)
+	assert.Equal(suite.T(), 1, stats.Voided)
+
+	var ids []string
+	scanner := bufio.NewScanner(&out)
+
+	for scanner.Scan() {
+		stmt := map[string]any{}
+		assert.Nil(suite.T(), json.Unmarshal(scanner.Bytes(), &stmt))
+		ids = append(ids, stmt["id"].(string))
+	}
+
+	assert.Equal(suite.T(), []string{
+		"00000000-0000-0000-0000-000000000002",
+		"00000000-0000-0000-0000-000000000001",
+		"00000000-0000-0000-0000-000000000003",
+	}, ids)
+}
+
+func (suite *BulkTestSuite) TestExportFailure() {
+	// The LRS rejects the second page with a JSON body
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("more") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "unauthorized"}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"statements": [%s], "more": "/statements?more=1"}`,
+			fmt.Sprintf(replicateStatement, "00000000-0000-0000-0000-000000000002", "http://adlnet.gov/expapi/verbs/experienced",
+				`{"objectType": "Activity", "id": "http://example.com/activity"}`, "2022-01-01T00:00:01.000Z"))
+	}))
+	defer source.Close()
+
+	lrs, err := client.NewRemoteLRS(source.URL+"/", "1.0.3", "Basic dGVzdDp0ZXN0")
+	assert.Nil(suite.T(), err)
+
+	stats, err := bulk.Export(lrs, io.Discard)
+
+	assert.ErrorContains(suite.T(), err, "lrs responded with 401")
+	assert.Equal(suite.T(), 1, stats.Statements)
+}
+
+func TestBulkTestSuite(t *testing.T) {
+	suite.Run(t, new(BulkTestSuite))
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/internal/cmd"
+	"github.com/burakkaraceylan/xapi-go/internal/config"
+	"github.com/burakkaraceylan/xapi-go/pkg/lrs"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ComposeTestSuite struct {
+	suite.Suite
+}
+
+// Runs compose with the answers, one per line, and returns what it printed on stdout and stderr
+func (suite *ComposeTestSuite) compose(answers ...string) (string, string, error) {
+	var out, errOut bytes.Buffer
+
+	err := cmd.Run([]string{"compose"}, strings.NewReader(strings.Join(answers, "\n")+"\n"), &out, &errOut)
+
+	return out.String(), errOut.String(), err
+}
+
+func (suite *ComposeTestSuite) TestCompose() {
+	out, prompts, err := suite.compose(
+		"Ada", "ada@example.com",
+		// An ambiguous verb prefix, then a unique one
+		"co", "compl", "",
+		// An invalid activity IRI, then a valid one
+		"not an iri", "http://example.com/course", "Course", "cour",
+		"y", "8", "0", "10", "y", "", "PT1M30S", "",
+	)
+
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), prompts, "did you mean commented, completed?")
+	assert.Contains(suite.T(), prompts, `"not an iri" is not an IRI`)
+
+	var stmt statement.Statement
+	assert.Nil(suite.T(), json.Unmarshal([]byte(out), &stmt))
+
+	assert.Equal(suite.T(), "Ada", *stmt.Actor.(*statement.Agent).Name)
+	assert.Equal(suite.T(), "http://adlnet.gov/expapi/verbs/completed", stmt.Verb.ID)
+	assert.Equal(suite.T(), "completed", stmt.Verb.Display["en-US"])
+
+	activity := stmt.Object.(*statement.Activity)
+	assert.Equal(suite.T(), "http://example.com/course", activity.ID)
+	assert.Equal(suite.T(), "http://adlnet.gov/expapi/activities/course", *activity.Definition.Type)
+
+	assert.Equal(suite.T(), 0.8, *stmt.Result.Score.Scaled)
+	assert.True(suite.T(), *stmt.Result.Success)
+	assert.Nil(suite.T(), stmt.Result.Completion)
+	assert.Equal(suite.T(), "PT1M30S", stmt.Result.Duration.String())
+}
+
+func (suite *ComposeTestSuite) TestUnknownVerb() {
+	_, prompts, err := suite.compose("Ada", "ada@example.com", "jumped")
+
+	assert.ErrorContains(suite.T(), err, "failed to read answer")
+	assert.Contains(suite.T(), prompts, `"jumped" is neither an IRI nor in the vocabulary`)
+}
+
+func (suite *ComposeTestSuite) TestEOF() {
+	out, _, err := suite.compose("Ada")
+
+	assert.ErrorContains(suite.T(), err, "failed to read answer: EOF")
+	assert.NotContains(suite.T(), out, "{")
+}
+
+func (suite *ComposeTestSuite) TestRunResetsFlags() {
+	server := httptest.NewServer(lrs.NewLRS())
+	defer server.Close()
+
+	suite.T().Setenv(config.EnvConfig, filepath.Join(suite.T().TempDir(), "config.yaml"))
+	suite.T().Setenv(config.EnvEndpoint, "")
+	suite.T().Setenv(config.EnvVersion, "")
+
+	var out bytes.Buffer
+
+	err := cmd.Run([]string{"about", "--endpoint", server.URL + "/", "--version", lrs.Version, "--auth", "Basic dGVzdDp0ZXN0"}, nil, &out, io.Discard)
+
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), out.String(), lrs.Version)
+
+	// The endpoint of the previous run isn't used again
+	err = cmd.Run([]string{"about"}, nil, io.Discard, io.Discard)
+
+	assert.ErrorContains(suite.T(), err, "you have to provide an endpoint")
+}
+
+func TestComposeTestSuite(t *testing.T) {
+	suite.Run(t, new(ComposeTestSuite))
+}
@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ConfigTestSuite) TestMissingFiles() {
+	dir := suite.T().TempDir()
+
+	cfg, err := config.Load(filepath.Join(dir, "config.yaml"))
+
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), cfg.Profiles)
+
+	credentials, err := config.LoadCredentials(filepath.Join(dir, "credentials.yaml"))
+
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), credentials)
+}
+
+func (suite *ConfigTestSuite) TestSaveAndResolve() {
+	path := filepath.Join(suite.T().TempDir(), "xapi-go", "config.yaml")
+
+	cfg := config.Config{
+		Default: "prod",
+		Profiles: map[string]*config.Profile{
+			"prod":    {Endpoint: "https://lrs.example.com/xapi/", Version: "1.0.3"},
+			"staging": {Endpoint: "https://staging.example.com/xapi/", Version: "1.0.3", Credentials: config.Credentials{Auth: "Basic abc"}},
+		},
+	}
+
+	assert.Nil(suite.T(), cfg.Save(path))
+	assert.Nil(suite.T(), config.SaveCredentials(config.CredentialsPath(path), map[string]config.Credentials{
+		"prod":    {Username: "user", Password: "secret"},
+		"staging": {Username: "ignored", Password: "ignored"},
+	}))
+
+	loaded, err := config.Load(path)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"prod", "staging"}, loaded.Names())
+
+	p, err := loaded.Resolve("", config.CredentialsPath(path))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "https://lrs.example.com/xapi/", p.Endpoint)
+	assert.Equal(suite.T(), "user", p.Username)
+	assert.Equal(suite.T(), "secret", p.Password)
+
+	// Credentials written in the profile take precedence over the credentials file
+	p, err = loaded.Resolve("staging", config.CredentialsPath(path))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "Basic abc", p.Auth)
+	assert.Empty(suite.T(), p.Username)
+
+	_, err = loaded.Resolve("missing", config.CredentialsPath(path))
+
+	assert.NotNil(suite.T(), err)
+
+	loaded.Default = ""
+	p, err = loaded.Resolve("", config.CredentialsPath(path))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), config.Profile{}, *p)
+}
+
+func (suite *ConfigTestSuite) TestEmptyProfile() {
+	dir := suite.T().TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	assert.Nil(suite.T(), os.WriteFile(path, []byte("default: foo\nprofiles:\n  foo:\n"), 0644))
+
+	cfg, err := config.Load(path)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"foo"}, cfg.Names())
+
+	p, err := cfg.Resolve("", config.CredentialsPath(path))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), config.Profile{}, *p)
+}
+
+func (suite *ConfigTestSuite) TestCredentialsPermissions() {
+	if runtime.GOOS == "windows" {
+		suite.T().Skip("file modes aren't enforced on windows")
+	}
+
+	path := filepath.Join(suite.T().TempDir(), "credentials.yaml")
+
+	assert.Nil(suite.T(), config.SaveCredentials(path, map[string]config.Credentials{"prod": {Auth: "Basic abc"}}))
+
+	info, err := os.Stat(path)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), os.FileMode(0600), info.Mode().Perm())
+
+	assert.Nil(suite.T(), os.Chmod(path, 0644))
+
+	_, err = config.LoadCredentials(path)
+
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *ConfigTestSuite) TestMerge() {
+	p := config.Profile{
+		Endpoint:    "https://lrs.example.com/xapi/",
+		Version:     "1.0.3",
+		Credentials: config.Credentials{Username: "user", Password: "secret"},
+	}
+
+	p.Merge(config.Profile{Version: "2.0.0"})
+
+	assert.Equal(suite.T(), "https://lrs.example.com/xapi/", p.Endpoint)
+	assert.Equal(suite.T(), "2.0.0", p.Version)
+	assert.Equal(suite.T(), "user", p.Username)
+
+	// Credentials are replaced as a whole
+	p.Merge(config.Profile{Credentials: config.Credentials{Auth: "Bearer token"}})
+
+	assert.Equal(suite.T(), config.Credentials{Auth: "Bearer token"}, p.Credentials)
+}
+
+func (suite *ConfigTestSuite) TestFromEnv() {
+	suite.T().Setenv(config.EnvEndpoint, "https://env.example.com/xapi/")
+	suite.T().Setenv(config.EnvVersion, "")
+	suite.T().Setenv(config.EnvUsername, "")
+	suite.T().Setenv(config.EnvPassword, "")
+	suite.T().Setenv(config.EnvAuth, "Basic env")
+
+	p := config.Profile{Endpoint: "https://lrs.example.com/xapi/", Version: "1.0.3"}
+	p.Merge(config.FromEnv())
+
+	assert.Equal(suite.T(), "https://env.example.com/xapi/", p.Endpoint)
+	assert.Equal(suite.T(), "1.0.3", p.Version)
+	assert.Equal(suite.T(), "Basic env", p.Auth)
+}
+
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
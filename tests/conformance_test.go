@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConformanceTestSuite struct {
+	suite.Suite
+}
+
+// Wraps single context activities in arrays, the form the spec requires statements to be returned in
+func arrayContextActivities(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, child := range value {
+			value[k] = arrayContextActivities(child)
+		}
+
+		if ca, ok := value["contextActivities"].(map[string]any); ok {
+			for k, child := range ca {
+				if single, ok := child.(map[string]any); ok {
+					ca[k] = []any{single}
+				}
+			}
+		}
+	case []any:
+		for i, child := range value {
+			value[i] = arrayContextActivities(child)
+		}
+	}
+
+	return v
+}
+
+func (suite *ConformanceTestSuite) TestRoundTrip() {
+	files, err := filepath.Glob(filepath.Join("testdata", "conformance", "*.json"))
+
+	assert.Nil(suite.T(), err)
+	assert.NotEmpty(suite.T(), files)
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		assert.Nil(suite.T(), err, file)
+
+		stmt := statement.Statement{}
+
+		if !assert.Nil(suite.T(), json.Unmarshal(b, &stmt), file) {
+			continue
+		}
+
+		encoded, err := json.Marshal(stmt)
+		assert.Nil(suite.T(), err, file)
+
+		var expected, actual any
+
+		assert.Nil(suite.T(), json.Unmarshal(b, &expected), file)
+		assert.Nil(suite.T(), json.Unmarshal(encoded, &actual), file)
+
+		assert.Equal(suite.T(), arrayContextActivities(expected), actual, file)
+	}
+}
+
+func (suite *ConformanceTestSuite) TestPolymorphicFields() {
+	b, err := os.ReadFile(filepath.Join("testdata", "conformance", "substatement.json"))
+	assert.Nil(suite.T(), err)
+
+	stmt := statement.Statement{}
+	assert.Nil(suite.T(), json.Unmarshal(b, &stmt))
+
+	sub, ok := stmt.Object.(*statement.SubStatement)
+
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "SubStatement", sub.ObjectType)
+	assert.Nil(suite.T(), sub.Authority)
+	assert.IsType(suite.T(), &statement.Activity{}, sub.Object)
+
+	instructor, ok := sub.Context.Instructor.(*statement.Agent)
+
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "mailto:teacher@example.com", *instructor.Mbox)
+
+	b, err = os.ReadFile(filepath.Join("testdata", "conformance", "single_context_activity.json"))
+	assert.Nil(suite.T(), err)
+
+	stmt = statement.Statement{}
+	assert.Nil(suite.T(), json.Unmarshal(b, &stmt))
+
+	assert.Equal(suite.T(), "http://example.com/activities/course", stmt.Context.ContextActivities.Parent[0].ID)
+	assert.IsType(suite.T(), &statement.Activity{}, stmt.Object)
+	assert.IsType(suite.T(), &statement.Agent{}, stmt.Actor)
+}
+
+func TestConformanceTestSuite(t *testing.T) {
+	suite.Run(t, new(ConformanceTestSuite))
+}
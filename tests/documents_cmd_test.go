@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/internal/cmd"
+	"github.com/burakkaraceylan/xapi-go/internal/config"
+	"github.com/burakkaraceylan/xapi-go/pkg/lrs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DocumentsCmdTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+}
+
+func (suite *DocumentsCmdTestSuite) SetupTest() {
+	suite.server = httptest.NewServer(lrs.NewLRS())
+
+	suite.T().Setenv(config.EnvConfig, filepath.Join(suite.T().TempDir(), "config.yaml"))
+}
+
+func (suite *DocumentsCmdTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+// Runs a state command of the test activity and agent with the input, and returns what it printed on stdout and stderr
+func (suite *DocumentsCmdTestSuite) state(input string, args ...string) (string, string, error) {
+	var out, errOut bytes.Buffer
+
+	args = append(append([]string{"state"}, args...),
+		"--activity", "http://example.com/activity", "--agent-mbox", "mailto:ada@example.com",
+		"--endpoint", suite.server.URL+"/", "--version", lrs.Version, "--auth", "Basic dGVzdDp0ZXN0")
+	err := cmd.Run(args, strings.NewReader(input), &out, &errOut)
+
+	return out.String(), errOut.String(), err
+}
+
+func (suite *DocumentsCmdTestSuite) TestOutput() {
+	for _, id := range []string{"bookmark", "progress"} {
+		_, _, err := suite.state(`{"page": 3}`, "put", id)
+		assert.Nil(suite.T(), err)
+	}
+
+	out, _, err := suite.state("", "list")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"ID", "bookmark", "progress"}, strings.Fields(out))
+
+	out, _, err = suite.state("", "list", "--output", "ndjson")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "{\"id\":\"bookmark\"}\n{\"id\":\"progress\"}\n", out)
+
+	// Without an output format, the body is printed as is
+	out, _, err = suite.state("", "get", "bookmark")
+
+	assert.Nil(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"page": 3}`, out)
+
+	out, _, err = suite.state("", "get", "bookmark", "--output", "json")
+
+	assert.Nil(suite.T(), err)
+
+	record := map[string]any{}
+	assert.Nil(suite.T(), json.Unmarshal([]byte(out), &record))
+
+	assert.Equal(suite.T(), "bookmark", record["id"])
+	assert.Equal(suite.T(), "application/json", record["contentType"])
+	assert.NotEmpty(suite.T(), record["etag"])
+	assert.NotEmpty(suite.T(), record["lastModified"])
+	assert.Equal(suite.T(), map[string]any{"page": float64(3)}, record["content"])
+
+	out, _, err = suite.state("", "get", "bookmark", "--fields", "id,content.page")
+
+	assert.Nil(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"id": "bookmark", "content.page": 3}`, out)
+
+	_, _, err = suite.state("", "get", "bookmark", "--output", "json", "--file", filepath.Join(suite.T().TempDir(), "bookmark.json"))
+
+	assert.ErrorContains(suite.T(), err, "none of the others can be")
+}
+
+// Returns a header of a document printed by get on stderr
+func (suite *DocumentsCmdTestSuite) header(id string, name string) string {
+	_, info, err := suite.state("", "get", id)
+	assert.Nil(suite.T(), err)
+
+	for _, line := range strings.Split(info, "\n") {
+		if strings.HasPrefix(line, name+": ") {
+			return strings.TrimPrefix(line, name+": ")
+		}
+	}
+
+	return ""
+}
+
+func (suite *DocumentsCmdTestSuite) TestContentType() {
+	dir := suite.T().TempDir()
+	text := filepath.Join(dir, "notes.txt")
+	assert.Nil(suite.T(), os.WriteFile(text, []byte(`{"not": "json by extension"}`), 0600))
+
+	_, _, err := suite.state(`{"page": 3}`, "put", "json")
+	assert.Nil(suite.T(), err)
+
+	_, _, err = suite.state("page 3", "put", "text")
+	assert.Nil(suite.T(), err)
+
+	_, _, err = suite.state("", "put", "file", text)
+	assert.Nil(suite.T(), err)
+
+	_, _, err = suite.state("page 3", "put", "explicit", "--content-type", "application/x-bookmark")
+	assert.Nil(suite.T(), err)
+
+	assert.Equal(suite.T(), "application/json", suite.header("json", "Content-Type"))
+	assert.Equal(suite.T(), "text/plain; charset=utf-8", suite.header("text", "Content-Type"))
+	assert.Equal(suite.T(), "text/plain; charset=utf-8", suite.header("file", "Content-Type"))
+	assert.Equal(suite.T(), "application/x-bookmark", suite.header("explicit", "Content-Type"))
+}
+
+func (suite *DocumentsCmdTestSuite) TestInfo() {
+	_, _, err := suite.state("page 3", "put", "bookmark")
+	assert.Nil(suite.T(), err)
+
+	out, info, err := suite.state("", "get", "bookmark")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "page 3", out)
+	assert.Regexp(suite.T(), `^ETag: "[0-9a-f]+"\n`, info)
+	assert.Regexp(suite.T(), `\nLast-Modified: \w{3}, \d{2} \w{3} \d{4} \d{2}:\d{2}:\d{2} GMT\n`, info)
+
+	etag := suite.header("bookmark", "ETag")
+
+	// The body goes to the file, the metadata still to stderr
+	file := filepath.Join(suite.T().TempDir(), "bookmark")
+
+	out, info, err = suite.state("", "get", "bookmark", "--file", file)
+
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), out)
+	assert.Contains(suite.T(), info, "ETag: "+etag+"\n")
+
+	b, err := os.ReadFile(file)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "page 3", string(b))
+}
+
+func (suite *DocumentsCmdTestSuite) TestETag() {
+	_, _, err := suite.state("page 3", "put", "bookmark")
+	assert.Nil(suite.T(), err)
+
+	etag := suite.header("bookmark", "ETag")
+
+	_, _, err = suite.state("page 4", "put", "bookmark", "--etag", `"stale"`)
+
+	assert.ErrorContains(suite.T(), err, "lrs responded with 412")
+
+	_, _, err = suite.state("", "delete", "bookmark", "--etag", `"stale"`)
+
+	assert.ErrorContains(suite.T(), err, "lrs responded with 412")
+
+	out, _, err := suite.state("", "get", "bookmark")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "page 3", out)
+
+	_, _, err = suite.state("page 4", "put", "bookmark", "--etag", etag)
+	assert.Nil(suite.T(), err)
+
+	out, _, err = suite.state("", "get", "bookmark")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "page 4", out)
+
+	// The ETag changed along with the content
+	_, _, err = suite.state("", "delete", "bookmark", "--etag", etag)
+
+	assert.ErrorContains(suite.T(), err, "lrs responded with 412")
+
+	_, _, err = suite.state("", "delete", "bookmark", "--etag", suite.header("bookmark", "ETag"))
+	assert.Nil(suite.T(), err)
+
+	_, _, err = suite.state("", "get", "bookmark")
+
+	assert.ErrorContains(suite.T(), err, "lrs responded with 404")
+}
+
+func TestDocumentsCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(DocumentsCmdTestSuite))
+}
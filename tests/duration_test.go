@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DurationTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DurationTestSuite) TestParse() {
+	cases := map[string]time.Duration{
+		"PT1H2M3.45S": time.Hour + 2*time.Minute + 3450*time.Millisecond,
+		"PT0S":        0,
+		"PT90M":       90 * time.Minute,
+		"P1D":         24 * time.Hour,
+		"P1W":         7 * 24 * time.Hour,
+		"P1DT12H":     36 * time.Hour,
+		"PT0,5S":      500 * time.Millisecond,
+		"P0Y0M0DT1M":  time.Minute,
+		"PT1.5H":      90 * time.Minute,
+	}
+
+	for s, expected := range cases {
+		d, err := statement.ParseDuration(s)
+
+		assert.Nil(suite.T(), err, s)
+		assert.Equal(suite.T(), expected, d.ToTimeDuration(), s)
+	}
+
+	// Nominal units are approximated
+	d, err := statement.ParseDuration("P1Y2M")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 425*24*time.Hour, d.ToTimeDuration())
+
+	for _, s := range []string{"", "P", "PT", "1H", "PT1H2", "PT-1S", "P1H", "PT1S2M", "pt1s", "P1.5Y2M", "PT1.5H30M"} {
+		_, err := statement.ParseDuration(s)
+		assert.NotNil(suite.T(), err, s)
+	}
+}
+
+func (suite *DurationTestSuite) TestString() {
+	cases := map[time.Duration]string{
+		0: "PT0S",
+		time.Hour + 2*time.Minute + 3450*time.Millisecond: "PT1H2M3.45S",
+		3456 * time.Millisecond:                           "PT3.45S",
+		26 * time.Hour:                                    "PT26H",
+		time.Millisecond:                                  "PT0S",
+		-time.Millisecond:                                 "PT0S",
+		time.Minute + 100*time.Millisecond:                "PT1M0.1S",
+	}
+
+	for d, expected := range cases {
+		assert.Equal(suite.T(), expected, statement.NewDuration(d).String())
+	}
+
+	// Parsed durations are formatted as they were written
+	for _, s := range []string{"P1M", "P1Y2M3W4DT5H6M7.89S", "PT90M", "P0Y0M0DT1M", "PT0,5S", "P1DT0S", "PT1.5H", "P1.5M"} {
+		d, err := statement.ParseDuration(s)
+
+		assert.Nil(suite.T(), err, s)
+		assert.Equal(suite.T(), s, d.String())
+	}
+
+	// Durations more precise than 0.01 second are truncated
+	truncated := map[string]string{
+		"PT1.2345S":   "PT1.23S",
+		"PT1,2345S":   "PT1,23S",
+		"PT0.001S":    "PT0S",
+		"PT2.1001S":   "PT2.1S",
+		"P1DT0.009S":  "P1DT0S",
+		"PT1.00001H":  "PT1H0.03S",
+		"PT0.0001M":   "PT0M",
+		"PT1.000001H": "PT1H",
+	}
+
+	for s, expected := range truncated {
+		d, err := statement.ParseDuration(s)
+
+		assert.Nil(suite.T(), err, s)
+		assert.Equal(suite.T(), expected, d.String(), s)
+	}
+}
+
+func (suite *DurationTestSuite) TestJSON() {
+	result := statement.Result{Duration: statement.NewDuration(time.Hour + 2*time.Minute + 3450*time.Millisecond)}
+
+	b, err := json.Marshal(result)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), `{"duration":"PT1H2M3.45S"}`, string(b))
+
+	parsed := statement.Result{}
+
+	assert.Nil(suite.T(), json.Unmarshal(b, &parsed))
+	assert.Equal(suite.T(), *result.Duration, *parsed.Duration)
+
+	assert.Nil(suite.T(), json.Unmarshal([]byte(`{"duration":"P1M"}`), &parsed))
+
+	b, err = json.Marshal(parsed)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), `{"duration":"P1M"}`, string(b))
+
+	assert.NotNil(suite.T(), json.Unmarshal([]byte(`{"duration":"1 hour"}`), &parsed))
+	assert.NotNil(suite.T(), json.Unmarshal([]byte(`{"duration":3600}`), &parsed))
+
+	_, err = json.Marshal(statement.Result{Duration: statement.NewDuration(-time.Second)})
+	assert.NotNil(suite.T(), err)
+}
+
+func TestDurationTestSuite(t *testing.T) {
+	suite.Run(t, new(DurationTestSuite))
+}
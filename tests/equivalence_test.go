@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type EquivalenceTestSuite struct {
+	suite.Suite
+}
+
+func (suite *EquivalenceTestSuite) parse(s string) statement.Statement {
+	stmt := statement.Statement{}
+
+	if err := json.Unmarshal([]byte(s), &stmt); err != nil {
+		panic(err)
+	}
+
+	return stmt
+}
+
+func (suite *EquivalenceTestSuite) TestEquivalent() {
+	a := suite.parse(`{
+		"id": "6F3C8B0E-3F4E-4D3A-9A59-1B1A5D0D3C11",
+		"actor": {
+			"objectType": "Group",
+			"members": [
+				{"objectType": "Agent", "mbox": "mailto:a@example.com"},
+				{"objectType": "Agent", "mbox": "mailto:b@example.com"}
+			]
+		},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/completed", "display": {"en-US": "completed"}},
+		"object": {"objectType": "Activity", "id": "http://example.com/activity", "definition": {"name": {"en-US": "Activity"}}},
+		"context": {"registration": "EC531277-B57B-4C15-8D91-D292C5B2B8F7"},
+		"result": {"duration": "PT1,2345S"},
+		"timestamp": "2022-01-01T12:00:00.123456+02:00",
+		"attachments": [
+			{"usageType": "http://example.com/a", "display": {"en-US": "A"}, "contentType": "text/plain", "length": 1, "sha2": "AA"},
+			{"usageType": "http://example.com/b", "display": {"en-US": "B"}, "contentType": "text/plain", "length": 1, "sha2": "BB"}
+		]
+	}`)
+
+	b := suite.parse(`{
+		"id": "6f3c8b0e-3f4e-4d3a-9a59-1b1a5d0d3c11",
+		"actor": {
+			"objectType": "Group",
+			"members": [
+				{"objectType": "Agent", "mbox": "mailto:b@example.com"},
+				{"objectType": "Agent", "mbox": "mailto:a@example.com"}
+			]
+		},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/completed", "display": {"en-us": "completed"}},
+		"object": {"objectType": "Activity", "id": "http://example.com/activity", "definition": {"name": {"EN-US": "Activity"}}},
+		"context": {"registration": "ec531277-b57b-4c15-8d91-d292c5b2b8f7"},
+		"result": {"duration": "PT1.23S"},
+		"timestamp": "2022-01-01T10:00:00.123Z",
+		"stored": "2022-01-01T10:00:01.000Z",
+		"version": "1.0.3",
+		"authority": {"objectType": "Agent", "mbox": "mailto:lrs@example.com"},
+		"attachments": [
+			{"usageType": "http://example.com/b", "display": {"en-US": "B"}, "contentType": "text/plain", "length": 1, "sha2": "bb"},
+			{"usageType": "http://example.com/a", "display": {"en-US": "A"}, "contentType": "text/plain", "length": 1, "sha2": "aa"}
+		]
+	}`)
+
+	equal, diffs := statement.Equivalent(a, b)
+
+	assert.True(suite.T(), equal)
+	assert.Empty(suite.T(), diffs)
+}
+
+func (suite *EquivalenceTestSuite) TestDifferent() {
+	a := suite.parse(`{
+		"actor": {"objectType": "Agent", "mbox": "mailto:a@example.com"},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/completed", "display": {"en-US": "completed"}},
+		"object": {"objectType": "Activity", "id": "http://example.com/activity"},
+		"timestamp": "2022-01-01T10:00:00.123Z"
+	}`)
+
+	b := suite.parse(`{
+		"actor": {"objectType": "Agent", "mbox": "mailto:a@example.com"},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/failed", "display": {"en-US": "failed"}},
+		"object": {"objectType": "Activity", "id": "http://example.com/activity"},
+		"timestamp": "2022-01-01T10:00:00.124Z"
+	}`)
+
+	equal, diffs := statement.Equivalent(a, b)
+
+	assert.False(suite.T(), equal)
+
+	paths := []string{}
+
+	for _, d := range diffs {
+		paths = append(paths, d.Path)
+	}
+
+	assert.Equal(suite.T(), []string{"timestamp", "verb.display.en-us", "verb.id"}, paths)
+	assert.Equal(suite.T(), "http://adlnet.gov/expapi/verbs/completed", diffs[2].A)
+	assert.Equal(suite.T(), "http://adlnet.gov/expapi/verbs/failed", diffs[2].B)
+}
+
+func (suite *EquivalenceTestSuite) TestDefaultObjectType() {
+	verb := *statement.NewVerb("http://adlnet.gov/expapi/verbs/completed", statement.LanguageMap{"en-US": "completed"})
+
+	a := statement.NewStatement(statement.Agent{Mbox: utils.Ptr("mailto:a@example.com")}, verb, statement.Activity{ID: "http://example.com/activity"})
+	b := statement.NewStatement(statement.NewAnonymousAgentWithMbox("mailto:a@example.com"), verb, statement.NewActivity("http://example.com/activity"))
+
+	equal, _ := statement.Equivalent(*a, *b)
+
+	assert.True(suite.T(), equal)
+}
+
+func TestEquivalenceTestSuite(t *testing.T) {
+	suite.Run(t, new(EquivalenceTestSuite))
+}
@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExtensionsTestSuite struct {
+	suite.Suite
+}
+
+type sessionExtension struct {
+	ID    string `json:"id"`
+	Tries int    `json:"tries"`
+}
+
+func (suite *ExtensionsTestSuite) TestGetAndSet() {
+	ext := statement.SetExtension(nil, "http://example.com/ext/score", 42)
+	ext = statement.SetExtension(ext, "http://example.com/ext/session", sessionExtension{ID: "abc", Tries: 2})
+
+	// Values read back from JSON are json.Number and map values
+	b, err := json.Marshal(ext)
+	assert.Nil(suite.T(), err)
+
+	decoded := statement.Extensions{}
+	assert.Nil(suite.T(), json.Unmarshal(b, &decoded))
+
+	for _, e := range []*statement.Extensions{ext, &decoded} {
+		score, err := statement.GetExtension[int](e, "http://example.com/ext/score")
+
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), 42, score)
+
+		session, err := statement.GetExtension[sessionExtension](e, "http://example.com/ext/session")
+
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), sessionExtension{ID: "abc", Tries: 2}, session)
+	}
+
+	_, err = statement.GetExtension[string](&decoded, "http://example.com/ext/score")
+	assert.NotNil(suite.T(), err)
+
+	_, err = statement.GetExtension[int](&decoded, "http://example.com/ext/missing")
+	assert.ErrorIs(suite.T(), err, statement.ErrExtensionNotFound)
+
+	_, err = statement.GetExtension[int](nil, "http://example.com/ext/score")
+	assert.ErrorIs(suite.T(), err, statement.ErrExtensionNotFound)
+}
+
+func (suite *ExtensionsTestSuite) TestLargeIntegers() {
+	// 2^53 + 1 can't be held by a float64
+	const large int64 = 9007199254740993
+
+	ext := statement.SetExtension(nil, "http://example.com/ext/id", large)
+	ext = statement.SetExtension(ext, "http://example.com/ext/ids", map[string]int64{"first": large})
+
+	b, err := json.Marshal(ext)
+
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), string(b), "9007199254740993")
+
+	var result statement.Result
+	assert.Nil(suite.T(), json.Unmarshal([]byte(`{"extensions": `+string(b)+`}`), &result))
+
+	for _, e := range []*statement.Extensions{ext, result.Extensions} {
+		id, err := statement.GetExtension[int64](e, "http://example.com/ext/id")
+
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), large, id)
+
+		ids, err := statement.GetExtension[map[string]int64](e, "http://example.com/ext/ids")
+
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), large, ids["first"])
+	}
+
+	raw, err := statement.GetExtension[interface{}](result.Extensions, "http://example.com/ext/id")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), json.Number("9007199254740993"), raw)
+
+	b, err = json.Marshal(result.Extensions)
+
+	assert.Nil(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"http://example.com/ext/id": 9007199254740993, "http://example.com/ext/ids": {"first": 9007199254740993}}`, string(b))
+}
+
+func (suite *ExtensionsTestSuite) TestRegistry() {
+	registry := statement.NewExtensionRegistry()
+
+	statement.RegisterExtension(registry, "http://example.com/ext/score", func(v int) error {
+		if v < 0 {
+			return errors.New("must not be negative")
+		}
+
+		return nil
+	})
+	statement.RegisterExtension[sessionExtension](registry, "http://example.com/ext/session")
+
+	ext := statement.Extensions{
+		"http://example.com/ext/score":   5,
+		"http://example.com/ext/session": map[string]interface{}{"id": "abc", "tries": 1},
+		"http://example.com/ext/other":   true,
+	}
+
+	assert.Nil(suite.T(), registry.Validate(&ext))
+
+	ext["http://example.com/ext/score"] = -1
+	ext["http://example.com/ext/session"] = "abc"
+	ext["not an iri"] = 1
+
+	err := registry.Validate(&ext)
+
+	var errs statement.ValidationErrors
+
+	assert.True(suite.T(), errors.As(err, &errs))
+	assert.Equal(suite.T(), 3, len(errs))
+	assert.Equal(suite.T(), `extensions["http://example.com/ext/score"]`, errs[0].Path)
+	assert.Equal(suite.T(), "must not be negative", errs[0].Message)
+	assert.Equal(suite.T(), `extensions["http://example.com/ext/session"]`, errs[1].Path)
+	assert.Equal(suite.T(), `extensions["not an iri"]`, errs[2].Path)
+	assert.Equal(suite.T(), "key is not an IRI", errs[2].Message)
+}
+
+func (suite *ExtensionsTestSuite) TestValidateResources() {
+	registry := statement.NewExtensionRegistry()
+	statement.RegisterExtension[int](registry, "http://example.com/ext/score")
+
+	invalid := statement.SetExtension(nil, "http://example.com/ext/score", "high")
+
+	ctx := statement.Context{Language: utils.Ptr("en_US"), Extensions: invalid}
+	err := ctx.Validate(registry)
+
+	assert.NotNil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(err.(statement.ValidationErrors)))
+
+	// Unregistered extensions pass with the default registry
+	ctx.Language = utils.Ptr("en-US")
+	assert.Nil(suite.T(), ctx.Validate())
+
+	result := statement.Result{Score: &statement.Score{Scaled: utils.Ptr(2.0)}, Extensions: invalid}
+	err = result.Validate(registry)
+
+	assert.NotNil(suite.T(), err)
+	assert.Equal(suite.T(), "score", err.(statement.ValidationErrors)[0].Path)
+
+	definition := statement.ActivityDefinition{Name: &statement.LanguageMap{"en-US": "a"}, Extensions: invalid}
+
+	assert.NotNil(suite.T(), definition.Validate(registry))
+
+	definition.Extensions = statement.SetExtension(nil, "http://example.com/ext/score", 3)
+	assert.Nil(suite.T(), definition.Validate(registry))
+}
+
+func TestExtensionsTestSuite(t *testing.T) {
+	suite.Run(t, new(ExtensionsTestSuite))
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type FormatTestSuite struct {
+	suite.Suite
+	Statement *statement.Statement
+}
+
+func (suite *FormatTestSuite) SetupTest() {
+	team := statement.NewGroup("Team")
+	team.AddMember(*statement.NewAgentWithMbox("Member", "mailto:member@example.com"))
+
+	activities := statement.NewContextActivityList()
+	activities.Append("Parent", *statement.NewActivityWithDefiniton("http://example.com/course", &statement.ActivityDefinition{
+		Name: &statement.LanguageMap{"en-US": "Course", "fr-FR": "Cours"},
+	}))
+
+	suite.Statement = statement.NewStatement(
+		statement.NewAgentWithMbox("Learner", "mailto:learner@example.com"),
+		*statement.NewVerb("http://adlnet.gov/expapi/verbs/answered", statement.LanguageMap{"en-US": "answered", "fr-FR": "a répondu", "de": "beantwortete"}),
+		statement.NewActivityWithDefiniton("http://example.com/question", &statement.ActivityDefinition{
+			Name:            &statement.LanguageMap{"en-US": "Question", "fr-FR": "Question FR"},
+			InteractionType: utils.Ptr("choice"),
+			Choices: []statement.InteractionComponent{
+				*statement.NewInteractionComponent("a", &statement.LanguageMap{"en-US": "A", "fr-FR": "A FR"}),
+			},
+		}),
+		&statement.StatementOptions{
+			Context: &statement.Context{
+				Instructor:        statement.NewAgentWithMbox("Instructor", "mailto:instructor@example.com"),
+				Team:              team,
+				ContextActivities: activities,
+			},
+		},
+	)
+}
+
+func (suite *FormatTestSuite) TestToIDsFormat() {
+	ids := suite.Statement.ToIDsFormat()
+
+	s, err := utils.ToJson(ids, false)
+	assert.Nil(suite.T(), err)
+
+	assert.NotContains(suite.T(), s, "name")
+	assert.NotContains(suite.T(), s, "display")
+	assert.NotContains(suite.T(), s, "definition")
+	assert.Contains(suite.T(), s, `"verb":{"id":"http://adlnet.gov/expapi/verbs/answered"}`)
+	assert.Contains(suite.T(), s, "mailto:member@example.com")
+	assert.Contains(suite.T(), s, "mailto:instructor@example.com")
+	assert.Contains(suite.T(), s, "http://example.com/course")
+
+	// The original statement is untouched
+	assert.Equal(suite.T(), "Learner", *suite.Statement.Actor.(*statement.Agent).Name)
+	assert.NotNil(suite.T(), suite.Statement.Object.(*statement.Activity).Definition)
+	assert.Equal(suite.T(), 3, len(suite.Statement.Verb.Display))
+}
+
+func (suite *FormatTestSuite) TestCanonicalize() {
+	c := suite.Statement.Canonicalize([]string{"fr-FR"})
+
+	assert.Equal(suite.T(), statement.LanguageMap{"fr-FR": "a répondu"}, c.Verb.Display)
+
+	def := c.Object.(*statement.Activity).Definition
+	assert.Equal(suite.T(), statement.LanguageMap{"fr-FR": "Question FR"}, *def.Name)
+	assert.Equal(suite.T(), statement.LanguageMap{"fr-FR": "A FR"}, *def.Choices[0].Description)
+	assert.Equal(suite.T(), statement.LanguageMap{"fr-FR": "Cours"}, *c.Context.ContextActivities.Parent[0].Definition.Name)
+
+	// Ranges are truncated, singletons included, until a tag matches
+	c = suite.Statement.Canonicalize([]string{"es", "en-us-x-private"})
+	assert.Equal(suite.T(), statement.LanguageMap{"en-US": "answered"}, c.Verb.Display)
+
+	c = suite.Statement.Canonicalize([]string{"de-CH-1996"})
+	assert.Equal(suite.T(), statement.LanguageMap{"de": "beantwortete"}, c.Verb.Display)
+
+	// Lookup never broadens the keys, fall back to a deterministic entry
+	c = suite.Statement.Canonicalize([]string{"fr-CA"})
+	assert.Equal(suite.T(), statement.LanguageMap{"de": "beantwortete"}, c.Verb.Display)
+
+	assert.Equal(suite.T(), 3, len(suite.Statement.Verb.Display))
+}
+
+func (suite *FormatTestSuite) TestAcceptLanguage() {
+	var header string
+	var format string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Accept-Language")
+		format = r.URL.Query().Get("format")
+		fmt.Fprint(w, `{"statements": [], "more": ""}`)
+	}))
+	defer server.Close()
+
+	lrs, err := client.NewRemoteLRS(server.URL+"/", "1.0.3", "Basic dGVzdDp0ZXN0")
+	assert.Nil(suite.T(), err)
+
+	_, _, err = lrs.QueryStatements(&client.StatementQueryParams{
+		Format:         utils.Ptr("canonical"),
+		AcceptLanguage: utils.Ptr("fr-CA, en;q=0.8"),
+	})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "fr-CA, en;q=0.8", header)
+	assert.Equal(suite.T(), "canonical", format)
+}
+
+func TestFormatTestSuite(t *testing.T) {
+	suite.Run(t, new(FormatTestSuite))
+}
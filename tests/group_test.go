@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
@@ -47,6 +48,81 @@ func (suite *GroupTestSuite) TestAnonymousGroup() {
 
 }
 
+func (suite *GroupTestSuite) TestIdentifiedGroup() {
+	g := statement.NewGroupWithAccount("test", statement.NewAccount("http://example.com", "team-1"))
+
+	assert.True(suite.T(), g.IsIdentified())
+	assert.False(suite.T(), statement.NewAnonymousGroup().IsIdentified())
+
+	ifi, err := g.IFI()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), statement.IFIAccount, ifi.Type)
+	assert.Equal(suite.T(), "account:http://example.com|team-1", g.Key())
+	assert.Equal(suite.T(), "", statement.NewAnonymousGroup().Key())
+
+	b, err := json.Marshal(g)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), `{"objectType":"Group","name":"test","account":{"homePage":"http://example.com","name":"team-1"}}`, string(b))
+
+	parsed := statement.Group{}
+
+	assert.Nil(suite.T(), json.Unmarshal(b, &parsed))
+	assert.Equal(suite.T(), *g, parsed)
+
+	g = statement.NewGroupWithMbox("test", "team@example.com")
+	assert.Equal(suite.T(), "mailto:team@example.com", *g.Mbox)
+
+	assert.Equal(suite.T(), "sha", *statement.NewGroupWithSHA1("test", "sha").MboxSHA1Sum)
+	assert.Equal(suite.T(), "http://example.com/openid", *statement.NewGroupWithOpenID("test", "http://example.com/openid").OpenID)
+}
+
+func (suite *GroupTestSuite) TestUniqueMembers() {
+	g := statement.NewAnonymousGroup()
+
+	assert.True(suite.T(), g.AddUniqueMember(suite.Agent))
+	assert.False(suite.T(), g.AddUniqueMember(*statement.NewAnonymousAgentWithMbox("bkaraceylan@gmail.com")))
+	assert.False(suite.T(), g.AddUniqueMember(*statement.NewAgentWithSHA1("Burak", "4f197a75e8f48f33ac17d07401a6430f0768ba0b")))
+	assert.True(suite.T(), g.AddUniqueMember(*statement.NewAgentWithOpenID("Other", "http://example.com/openid")))
+	assert.Equal(suite.T(), 2, len(g.Members))
+
+	g.AddMember(suite.Agent)
+	g.AddMember(*statement.NewAgentWithAccount("Third", statement.NewAccount("http://example.com", "third")))
+	g.DedupeMembers()
+
+	assert.Equal(suite.T(), 3, len(g.Members))
+	assert.Equal(suite.T(), suite.Agent, g.Members[0])
+	assert.Equal(suite.T(), "Third", *g.Members[2].Name)
+}
+
+func (suite *GroupTestSuite) TestExpand() {
+	other := *statement.NewAgentWithOpenID("Other", "http://example.com/openid")
+
+	g := statement.NewGroupWithMbox("test", "team@example.com")
+	g.AddMember(suite.Agent)
+	g.AddMember(other)
+	g.AddMember(suite.Agent)
+
+	assert.Equal(suite.T(), []statement.Agent{suite.Agent, other}, statement.ExpandActor(g))
+	assert.Equal(suite.T(), 3, len(g.Members))
+	assert.Equal(suite.T(), []statement.Agent{suite.Agent}, statement.ExpandActor(&suite.Agent))
+	assert.Nil(suite.T(), statement.ExpandActor(nil))
+
+	stmt := statement.NewStatement(
+		g,
+		*statement.NewVerb("http://adlnet.gov/expapi/verbs/completed", statement.LanguageMap{"en-US": "completed"}),
+		statement.NewActivity("http://github.com/bkaraceylan/xapi-go/Test/Unit/0"),
+	)
+
+	expanded := statement.ExpandStatement(*stmt)
+
+	assert.Equal(suite.T(), 2, len(expanded))
+	assert.Equal(suite.T(), &other, expanded[1].Actor)
+	assert.Equal(suite.T(), stmt.Verb, expanded[1].Verb)
+	assert.Equal(suite.T(), g, stmt.Actor)
+}
+
 func TestGroupTestSuite(t *testing.T) {
 	suite.Run(t, new(GroupTestSuite))
 }
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type IFITestSuite struct {
+	suite.Suite
+}
+
+const testMboxSHA1 = "4f197a75e8f48f33ac17d07401a6430f0768ba0b"
+
+func (suite *IFITestSuite) TestNormalizeMbox() {
+	mbox, err := statement.NormalizeMbox(" bkaraceylan@gmail.com ")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "mailto:bkaraceylan@gmail.com", mbox)
+
+	mbox, err = statement.NormalizeMbox("MAILTO:bkaraceylan@gmail.com")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "mailto:bkaraceylan@gmail.com", mbox)
+
+	for _, s := range []string{"", "mailto:", "not an email", "Burak <bkaraceylan@gmail.com>"} {
+		_, err := statement.NormalizeMbox(s)
+		assert.NotNil(suite.T(), err, s)
+	}
+
+	a := statement.NewAgentWithMbox("Burak Karaceylan", "bkaraceylan@gmail.com")
+	assert.Equal(suite.T(), "mailto:bkaraceylan@gmail.com", *a.Mbox)
+}
+
+func (suite *IFITestSuite) TestIFI() {
+	ifi, err := statement.NewAgentWithMbox("Burak Karaceylan", "mailto:bkaraceylan@gmail.com").IFI()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), statement.IFI{Type: statement.IFIMbox, Value: "mailto:bkaraceylan@gmail.com"}, ifi)
+
+	ifi, err = statement.NewAnonymousAgentWithSHA1("4F197A75E8F48F33AC17D07401A6430F0768BA0B").IFI()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), statement.IFI{Type: statement.IFIMboxSHA1Sum, Value: testMboxSHA1}, ifi)
+
+	ifi, err = statement.NewAnonymousAgentWithAccount(statement.NewAccount("http://example.com", "bkaraceylan")).IFI()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "account:http://example.com|bkaraceylan", ifi.String())
+
+	_, err = statement.Agent{ObjectType: "Agent"}.IFI()
+	assert.ErrorIs(suite.T(), err, statement.ErrNoIFI)
+
+	a := statement.NewAgentWithOpenID("Burak Karaceylan", "http://openid.example.com/bk")
+	a.Account = statement.NewAccount("http://example.com", "bkaraceylan")
+
+	_, err = a.IFI()
+	assert.ErrorIs(suite.T(), err, statement.ErrMultipleIFI)
+}
+
+func (suite *IFITestSuite) TestAnonymize() {
+	a := statement.NewAgentWithMbox("Burak Karaceylan", "mailto:bkaraceylan@gmail.com")
+
+	anonymized, err := a.Anonymize()
+
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), anonymized.Name)
+	assert.Nil(suite.T(), anonymized.Mbox)
+	assert.Equal(suite.T(), testMboxSHA1, *anonymized.MboxSHA1Sum)
+	assert.Equal(suite.T(), "Burak Karaceylan", *a.Name)
+
+	b, err := json.Marshal(anonymized)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), `{"objectType":"Agent","mbox_sha1sum":"`+testMboxSHA1+`"}`, string(b))
+
+	_, err = statement.NewAgentWithMbox("Burak Karaceylan", "invalid").Anonymize()
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *IFITestSuite) TestEqualAndKey() {
+	a := statement.NewAgentWithMbox("Burak Karaceylan", "mailto:bkaraceylan@gmail.com")
+	b := statement.NewAnonymousAgentWithMbox("bkaraceylan@gmail.com")
+	c := statement.NewAgentWithSHA1("Someone", testMboxSHA1)
+	d := statement.NewAgentWithOpenID("Burak Karaceylan", "http://openid.example.com/bk")
+
+	assert.True(suite.T(), a.Equal(*b))
+	assert.True(suite.T(), a.Equal(*c))
+	assert.False(suite.T(), a.Equal(*d))
+	assert.False(suite.T(), statement.Agent{}.Equal(statement.Agent{}))
+
+	counts := map[string]int{}
+
+	for _, agent := range []*statement.Agent{a, b, c, d} {
+		counts[agent.Key()]++
+	}
+
+	assert.Equal(suite.T(), 3, counts["mbox_sha1sum:"+testMboxSHA1])
+	assert.Equal(suite.T(), 1, counts["openid:http://openid.example.com/bk"])
+}
+
+func TestIFITestSuite(t *testing.T) {
+	suite.Run(t, new(IFITestSuite))
+}
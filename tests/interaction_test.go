@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type InteractionTestSuite struct {
+	suite.Suite
+	Components []statement.InteractionComponent
+}
+
+func (suite *InteractionTestSuite) SetupTest() {
+	suite.Components = []statement.InteractionComponent{
+		*statement.NewInteractionComponent("a", &statement.LanguageMap{"en-US": "A"}),
+		*statement.NewInteractionComponent("b", &statement.LanguageMap{"en-US": "B"}),
+		*statement.NewInteractionComponent("c", &statement.LanguageMap{"en-US": "C"}),
+	}
+}
+
+func (suite *InteractionTestSuite) TestTrueFalse() {
+	name := statement.LanguageMap{"en-US": "Question"}
+	ad := statement.NewTrueFalseInteraction(true, &statement.InteractionOptions{Name: &name})
+
+	assert.Equal(suite.T(), statement.InteractionTrueFalse, *ad.InteractionType)
+	assert.Equal(suite.T(), statement.ActivityTypeCMIInteraction, *ad.Type)
+	assert.Equal(suite.T(), name, *ad.Name)
+	assert.Equal(suite.T(), []string{"true"}, ad.CorrectResponsesPattern)
+}
+
+func (suite *InteractionTestSuite) TestChoiceAndSequencing() {
+	ad, err := statement.NewChoiceInteraction(suite.Components, []string{"a", "c"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"a[,]c"}, ad.CorrectResponsesPattern)
+	assert.Equal(suite.T(), 3, len(ad.Choices))
+
+	_, err = statement.NewChoiceInteraction(suite.Components, []string{"d"})
+	assert.EqualError(suite.T(), err, `unknown choice "d"`)
+
+	ad, err = statement.NewSequencingInteraction(suite.Components, []string{"c", "a", "b"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), statement.InteractionSequencing, *ad.InteractionType)
+	assert.Equal(suite.T(), []string{"c[,]a[,]b"}, ad.CorrectResponsesPattern)
+	assert.Nil(suite.T(), ad.AddChoice(*statement.NewInteractionComponent("d")))
+}
+
+func (suite *InteractionTestSuite) TestFillIn() {
+	ad := statement.NewFillInInteraction([]string{"Bob's your uncle"})
+	assert.Equal(suite.T(), []string{"Bob's your uncle"}, ad.CorrectResponsesPattern)
+
+	ad = statement.NewFillInInteraction([]string{"red", "blue"}, &statement.InteractionOptions{CaseMatters: utils.Ptr(false), OrderMatters: utils.Ptr(true)})
+	assert.Equal(suite.T(), []string{"{case_matters=false}{order_matters=true}red[,]blue"}, ad.CorrectResponsesPattern)
+
+	ad = statement.NewLongFillInInteraction([]string{"essay"}, &statement.InteractionOptions{CaseMatters: utils.Ptr(true), OrderMatters: utils.Ptr(true)})
+	assert.Equal(suite.T(), statement.InteractionLongFillIn, *ad.InteractionType)
+	assert.Equal(suite.T(), []string{"{case_matters=true}essay"}, ad.CorrectResponsesPattern)
+}
+
+func (suite *InteractionTestSuite) TestMatching() {
+	ad, err := statement.NewMatchingInteraction(suite.Components[:2], suite.Components[1:], [][2]string{{"a", "b"}, {"b", "c"}})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"a[.]b[,]b[.]c"}, ad.CorrectResponsesPattern)
+	assert.Nil(suite.T(), ad.AddMatching(*statement.NewInteractionComponent("d"), true))
+
+	_, err = statement.NewMatchingInteraction(suite.Components[:2], suite.Components[1:], [][2]string{{"a", "a"}})
+	assert.EqualError(suite.T(), err, `unknown target "a"`)
+}
+
+func (suite *InteractionTestSuite) TestPerformance() {
+	ad, err := statement.NewPerformanceInteraction(suite.Components, [][2]string{
+		{"a", "pong"},
+		{"b", statement.NumericRange(utils.Ptr(1.0), utils.Ptr(2.5))},
+		{"c", statement.NumericRange(nil, utils.Ptr(5.0))},
+	}, &statement.InteractionOptions{OrderMatters: utils.Ptr(false)})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"{order_matters=false}a[.]pong[,]b[.]1[:]2.5[,]c[.][:]5"}, ad.CorrectResponsesPattern)
+	assert.Nil(suite.T(), ad.AddSteps(*statement.NewInteractionComponent("d")))
+
+	_, err = statement.NewPerformanceInteraction(suite.Components, [][2]string{{"x", "1"}})
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *InteractionTestSuite) TestLikert() {
+	ad, err := statement.NewLikertInteraction(suite.Components, "b")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"b"}, ad.CorrectResponsesPattern)
+	assert.Nil(suite.T(), ad.AddScale(*statement.NewInteractionComponent("d")))
+
+	ad, err = statement.NewLikertInteraction(suite.Components, "")
+
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), ad.CorrectResponsesPattern)
+}
+
+func (suite *InteractionTestSuite) TestNumeric() {
+	ad, err := statement.NewNumericInteraction(utils.Ptr(1.0), utils.Ptr(5.0))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"1[:]5"}, ad.CorrectResponsesPattern)
+
+	ad, err = statement.NewNumericInteraction(utils.Ptr(4.0), utils.Ptr(4.0))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"4"}, ad.CorrectResponsesPattern)
+
+	ad, err = statement.NewNumericInteraction(utils.Ptr(10.0), nil)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"10[:]"}, ad.CorrectResponsesPattern)
+
+	_, err = statement.NewNumericInteraction(utils.Ptr(5.0), utils.Ptr(1.0))
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *InteractionTestSuite) TestOther() {
+	ad := statement.NewOtherInteraction([]string{"(35.937432,-86.868896)"})
+
+	assert.Equal(suite.T(), statement.InteractionOther, *ad.InteractionType)
+	assert.Equal(suite.T(), []string{"(35.937432,-86.868896)"}, ad.CorrectResponsesPattern)
+}
+
+func (suite *InteractionTestSuite) TestNilInteractionType() {
+	ad := statement.ActivityDefinition{}
+
+	assert.NotNil(suite.T(), ad.AddChoice(*statement.NewInteractionComponent("a")))
+	assert.NotNil(suite.T(), ad.AddScale(*statement.NewInteractionComponent("a")))
+	assert.NotNil(suite.T(), ad.AddMatching(*statement.NewInteractionComponent("a"), false))
+	assert.NotNil(suite.T(), ad.AddSteps(*statement.NewInteractionComponent("a")))
+}
+
+func TestInteractionTestSuite(t *testing.T) {
+	suite.Run(t, new(InteractionTestSuite))
+}
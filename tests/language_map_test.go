@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/text/language"
+)
+
+type LanguageMapTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LanguageMapTestSuite) TestBest() {
+	m := statement.LanguageMap{"en-US": "color", "en-GB": "colour", "fr": "couleur", "zh-Hant": "顏色"}
+
+	assert.Equal(suite.T(), "colour", m.Best(language.BritishEnglish))
+	assert.Equal(suite.T(), "couleur", m.Best(language.MustParse("fr-CA")))
+	assert.Equal(suite.T(), "couleur", m.Best(language.German, language.French))
+	assert.Equal(suite.T(), "顏色", m.Best(language.MustParse("zh-TW")))
+
+	// Without a match, the first entry in tag order is used
+	assert.Equal(suite.T(), "colour", m.Best(language.Japanese))
+	assert.Equal(suite.T(), "colour", m.Best())
+
+	// The undetermined entry is the fallback when present
+	m["und"] = "?"
+	assert.Equal(suite.T(), "?", m.Best(language.Japanese))
+
+	assert.Equal(suite.T(), "", statement.LanguageMap{}.Best(language.English))
+}
+
+func (suite *LanguageMapTestSuite) TestValidate() {
+	assert.Nil(suite.T(), statement.LanguageMap{"en-US": "a", "zh-Hant-TW": "b", "und": "c"}.Validate())
+
+	err := statement.LanguageMap{"en-US": "a", "en_US": "b", "not a tag": "c"}.Validate()
+
+	assert.EqualError(suite.T(), err, "invalid language tags: en_US, not a tag")
+}
+
+func (suite *LanguageMapTestSuite) TestMerge() {
+	a := statement.LanguageMap{"en-US": "color", "fr": "couleur"}
+	b := statement.LanguageMap{"en-us": "colour", "de": "Farbe"}
+
+	merged := a.Merge(b)
+
+	assert.Equal(suite.T(), statement.LanguageMap{"en-us": "colour", "fr": "couleur", "de": "Farbe"}, merged)
+	assert.Equal(suite.T(), statement.LanguageMap{"en-US": "color", "fr": "couleur"}, a)
+}
+
+func TestLanguageMapTestSuite(t *testing.T) {
+	suite.Run(t, new(LanguageMapTestSuite))
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/lint"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LintTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LintTestSuite) TestCorpus() {
+	files, err := filepath.Glob(filepath.Join("testdata", "conformance", "*.json"))
+	assert.Nil(suite.T(), err)
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		assert.Nil(suite.T(), err, file)
+
+		errs, err := lint.Lint(b)
+
+		assert.Nil(suite.T(), err, file)
+		assert.Empty(suite.T(), errs, file)
+	}
+}
+
+func (suite *LintTestSuite) TestProblems() {
+	errs, err := lint.Lint([]byte(`[
+		{
+			"actor": {"mbox": "mailto:learner@example.com", "email": "learner@example.com"},
+			"verb": {"id": "http://adlnet.gov/expapi/verbs/completed", "display": {"en-US": "completed"}},
+			"object": {"id": "http://example.com/activity", "definition": {"title": {"en-US": "Activity"}}},
+			"result": {"score": {"raw": 5, "percent": 50}}
+		},
+		{
+			"actor": {"objectType": "Group", "member": [{"mbox": "mailto:learner@example.com"}]},
+			"verb": {"id": "completed"},
+			"object": {"objectType": "StatementRef", "id": "1"}
+		},
+		{
+			"actor": {"mbox": "learner@example.com"},
+			"verb": {"id": 1},
+			"object": {"id": "http://example.com/activity"}
+		},
+		"statement"
+	]`))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), statement.ValidationErrors{
+		{Path: "[0].actor.email", Message: "unknown property"},
+		{Path: "[0].object.definition.title", Message: "unknown property"},
+		{Path: "[0].result.score.percent", Message: "unknown property"},
+		{Path: "[1].actor.member", Message: "unknown property"},
+		{Path: "[1].actor.members", Message: "anonymous groups must have members"},
+		{Path: "[1].verb.id", Message: "not an IRI"},
+		{Path: "[1].object.id", Message: "not a UUID"},
+		{Path: "[2].verb.id", Message: "unexpected number"},
+		{Path: "[3]", Message: "not an object"},
+	}, errs)
+}
+
+func (suite *LintTestSuite) TestInvalidJSON() {
+	_, err := lint.Lint([]byte("{\n  \"actor\": {\n    \"mbox\" \"mailto:learner@example.com\"\n  }\n}"))
+
+	assert.NotNil(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "line 3, column 12")
+
+	_, err = lint.Lint([]byte(`{"actor": {}} {}`))
+
+	assert.NotNil(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "line 1, column 15")
+
+	_, err = lint.Lint([]byte(`{"actor": `))
+
+	assert.NotNil(suite.T(), err)
+}
+
+func TestLintTestSuite(t *testing.T) {
+	suite.Run(t, new(LintTestSuite))
+}
@@ -0,0 +1,373 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/conformance"
+	"github.com/burakkaraceylan/xapi-go/pkg/lrs"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/documents"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LRSTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LRSTestSuite) run(handler http.Handler, params ...*conformance.Options) []conformance.Result {
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	remote, err := client.NewRemoteLRS(server.URL+"/", lrs.Version, "test", "test")
+	assert.Nil(suite.T(), err)
+
+	results, err := conformance.Run(remote, params...)
+	assert.Nil(suite.T(), err)
+
+	return results
+}
+
+func (suite *LRSTestSuite) TestConformance() {
+	results := suite.run(lrs.NewLRS(&lrs.Options{PageSize: 2}))
+
+	assert.Len(suite.T(), results, len(conformance.Requirements()))
+
+	for _, r := range results {
+		assert.True(suite.T(), r.Passed, "%s: %s", r.Requirement, r.Error)
+	}
+}
+
+func (suite *LRSTestSuite) TestFailure() {
+	// An LRS accepting requests without the version header
+	l := lrs.NewLRS()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.Header.Get("X-Experience-API-Version")) == 0 {
+			r.Header.Set("X-Experience-API-Version", lrs.Version)
+		}
+
+		l.ServeHTTP(w, r)
+	})
+
+	results := suite.run(handler, &conformance.Options{Requirements: []string{"version-header", "statement-put"}})
+
+	assert.Len(suite.T(), results, 2)
+	assert.Equal(suite.T(), "version-header", results[0].Requirement)
+	assert.False(suite.T(), results[0].Passed)
+	assert.Contains(suite.T(), results[0].Error, "expected status 400, got 200")
+	assert.True(suite.T(), results[1].Passed)
+}
+
+// Handlers breaking a single requirement each, by rewriting requests to and responses from a conforming LRS
+func nonConformingHandlers() map[string]http.Handler {
+	handlers := make(map[string]http.Handler)
+
+	// Accepts a different statement with an existing id
+	l := lrs.NewLRS()
+	handlers["statement-conflict"] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := httptest.NewRecorder()
+		l.ServeHTTP(recorder, r)
+
+		if r.Method == "PUT" && r.URL.Path == "/statements" && recorder.Code == http.StatusConflict {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		for k, v := range recorder.Header() {
+			w.Header()[k] = v
+		}
+
+		w.WriteHeader(recorder.Code)
+		w.Write(recorder.Body.Bytes())
+	})
+
+	// Pretends to void statements, which queries then still return
+	l = lrs.NewLRS()
+	voided := make(map[string]bool)
+	var mu sync.Mutex
+	handlers["statement-void"] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		q := r.URL.Query()
+
+		if r.Method == "POST" && r.URL.Path == "/statements" {
+			b, _ := io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(b))
+
+			var statements []statement.Statement
+
+			if json.Unmarshal(b, &statements) == nil && len(statements) == 1 && statements[0].Verb.ID == statement.VerbVoided {
+				voided[statements[0].Object.(*statement.StatementRef).ID] = true
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-Experience-API-Version", lrs.Version)
+				json.NewEncoder(w).Encode([]string{uuid.NewString()})
+				return
+			}
+		}
+
+		switch {
+		case voided[q.Get("statementId")]:
+			w.Header().Set("X-Experience-API-Version", lrs.Version)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case voided[q.Get("voidedStatementId")]:
+			q.Set("statementId", q.Get("voidedStatementId"))
+			q.Del("voidedStatementId")
+			r.URL.RawQuery = q.Encode()
+		}
+
+		l.ServeHTTP(w, r)
+	})
+
+	// Ignores If-Match
+	l = lrs.NewLRS()
+	handlers["document-etag"] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del("If-Match")
+		l.ServeHTTP(w, r)
+	})
+
+	// Returns the statements stored at since
+	l = lrs.NewLRS()
+	handlers["query-since-until"] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		if since, err := statement.ParseTimestamp(q.Get("since")); err == nil {
+			q.Set("since", statement.NewTimestamp(since.Add(-time.Millisecond)).String())
+			r.URL.RawQuery = q.Encode()
+		}
+
+		l.ServeHTTP(w, r)
+	})
+
+	return handlers
+}
+
+func (suite *LRSTestSuite) TestNonConforming() {
+	for requirement, handler := range nonConformingHandlers() {
+		// The handler passes every other requirement
+		results := suite.run(handler)
+		assert.Len(suite.T(), results, len(conformance.Requirements()))
+
+		for _, r := range results {
+			assert.Equal(suite.T(), r.Requirement != requirement, r.Passed, "%s: %s: %s", requirement, r.Requirement, r.Error)
+		}
+	}
+}
+
+func (suite *LRSTestSuite) TestUnknownRequirement() {
+	remote, err := client.NewRemoteLRS("http://127.0.0.1:1/", lrs.Version, "test", "test")
+	assert.Nil(suite.T(), err)
+
+	_, err = conformance.Run(remote, &conformance.Options{Requirements: []string{"unknown"}})
+
+	assert.EqualError(suite.T(), err, "unknown requirement unknown")
+}
+
+func (suite *LRSTestSuite) TestPersistence() {
+	path := filepath.Join(suite.T().TempDir(), "lrs.json")
+
+	l, err := lrs.Open(path)
+	assert.Nil(suite.T(), err)
+
+	server := httptest.NewServer(l)
+	remote, err := client.NewRemoteLRS(server.URL+"/", lrs.Version, "test", "test")
+	assert.Nil(suite.T(), err)
+
+	ids, _, err := remote.SaveStatements([]statement.Statement{
+		*statement.NewStatement(statement.NewAnonymousAgentWithMbox("learner@example.com"), *statement.NewVerb("http://adlnet.gov/expapi/verbs/completed", nil), statement.NewActivity("http://example.com/activity")),
+	})
+	assert.Nil(suite.T(), err)
+
+	_, resp, err := remote.SaveActivityProfile(&documents.ActivityDocument{
+		Document: documents.Document{ID: "profile", ContentType: "application/json", Content: []byte(`{"a": 1}`)},
+		Activity: *statement.NewActivity("http://example.com/activity"),
+	})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNoContent, resp.Status)
+
+	server.Close()
+
+	// A new LRS opened from the file has the statement and the document
+	l, err = lrs.Open(path)
+	assert.Nil(suite.T(), err)
+
+	server = httptest.NewServer(l)
+	defer server.Close()
+
+	remote.Endpoint = server.URL + "/"
+
+	stmt, resp, err := remote.GetStatement(ids[0])
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, resp.Status)
+	assert.NotNil(suite.T(), stmt.Stored)
+
+	doc, resp, err := remote.GetActivityProfile(*statement.NewActivity("http://example.com/activity"), "profile")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, resp.Status)
+	assert.Equal(suite.T(), `{"a": 1}`, string(doc.Content))
+
+	// Statements stored after reopening come after the loaded ones
+	more, _, err := remote.SaveStatements([]statement.Statement{
+		*statement.NewStatement(statement.NewAnonymousAgentWithMbox("learner@example.com"), *statement.NewVerb("http://adlnet.gov/expapi/verbs/attempted", nil), statement.NewActivity("http://example.com/activity")),
+	})
+	assert.Nil(suite.T(), err)
+
+	result, _, err := remote.QueryStatements(&client.StatementQueryParams{Ascending: utils.Ptr(true)})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{ids[0], more[0]}, []string{*result.Statements[0].ID, *result.Statements[1].ID})
+}
+
+func (suite *LRSTestSuite) TestPersistenceFailure() {
+	// The directory of the file doesn't exist, so the LRS can't be written to it
+	dir := filepath.Join(suite.T().TempDir(), "missing")
+
+	l, err := lrs.Open(filepath.Join(dir, "lrs.json"))
+	assert.Nil(suite.T(), err)
+
+	server := httptest.NewServer(l)
+	defer server.Close()
+
+	remote, err := client.NewRemoteLRS(server.URL+"/", lrs.Version, "test", "test")
+	assert.Nil(suite.T(), err)
+
+	stmt := statement.NewStatement(statement.NewAnonymousAgentWithMbox("learner@example.com"), *statement.NewVerb("http://adlnet.gov/expapi/verbs/completed", nil), statement.NewActivity("http://example.com/activity"))
+	stmt.ID = utils.Ptr("3f1d9d36-6c3f-4f4e-9d43-9c1a1b2f3e4d")
+
+	_, resp, err := remote.SaveStatements([]statement.Statement{*stmt})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, resp.Status)
+
+	activity := *statement.NewActivity("http://example.com/activity")
+	_, resp, err = remote.SaveActivityProfile(&documents.ActivityDocument{
+		Document: documents.Document{ID: "profile", ContentType: "application/json", Content: []byte(`{"a": 1}`)},
+		Activity: activity,
+	})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, resp.Status)
+
+	// Nothing was kept in memory
+	_, resp, err = remote.GetStatement(*stmt.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, resp.Status)
+
+	_, resp, err = remote.GetActivityProfile(activity, "profile")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, resp.Status)
+
+	// Once the file can be written, the statement can be stored again
+	assert.Nil(suite.T(), os.Mkdir(dir, 0700))
+
+	_, resp, err = remote.SaveStatements([]statement.Statement{*stmt})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, resp.Status)
+}
+
+func (suite *LRSTestSuite) TestOpenInvalid() {
+	path := filepath.Join(suite.T().TempDir(), "lrs.json")
+	assert.Nil(suite.T(), os.WriteFile(path, []byte("{"), 0600))
+
+	_, err := lrs.Open(path)
+
+	assert.ErrorContains(suite.T(), err, "failed to unmarshal")
+}
+
+func (suite *LRSTestSuite) TestAuth() {
+	server := httptest.NewServer(lrs.NewLRS(&lrs.Options{Username: "author", Password: "secret"}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL+"/statements", nil)
+	req.Header.Set("X-Experience-API-Version", lrs.Version)
+	req.SetBasicAuth("author", "wrong")
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(suite.T(), err)
+	resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(suite.T(), `Basic realm="xapi-go"`, resp.Header.Get("WWW-Authenticate"))
+
+	// About doesn't require credentials
+	remote, err := client.NewRemoteLRS(server.URL+"/", lrs.Version, "author", "wrong")
+	assert.Nil(suite.T(), err)
+
+	about, err := remote.About()
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), about.Version, lrs.Version)
+
+	remote, err = client.NewRemoteLRS(server.URL+"/", lrs.Version, "author", "secret")
+	assert.Nil(suite.T(), err)
+
+	ids, lrsResp, err := remote.SaveStatements([]statement.Statement{
+		*statement.NewStatement(statement.NewAnonymousAgentWithMbox("learner@example.com"), *statement.NewVerb("http://adlnet.gov/expapi/verbs/completed", nil), statement.NewActivity("http://example.com/activity")),
+	})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, lrsResp.Status)
+
+	// The authority is named after the username
+	stmt, _, err := remote.GetStatement(ids[0])
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "author", stmt.Authority.(*statement.Agent).Account.Name)
+}
+
+func (suite *LRSTestSuite) TestCORS() {
+	server := httptest.NewServer(lrs.CORS(lrs.NewLRS(&lrs.Options{Username: "author", Password: "secret"})))
+	defer server.Close()
+
+	// Preflight requests are answered without credentials
+	req, _ := http.NewRequest("OPTIONS", server.URL+"/statements", nil)
+	req.Header.Set("Origin", "http://content.example.com")
+	req.Header.Set("Access-Control-Request-Method", "PUT")
+	req.Header.Set("Access-Control-Request-Headers", "authorization, x-experience-api-version")
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(suite.T(), err)
+	resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	assert.Equal(suite.T(), "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Empty(suite.T(), resp.Header.Get("Access-Control-Allow-Credentials"))
+	assert.Equal(suite.T(), "authorization, x-experience-api-version", resp.Header.Get("Access-Control-Allow-Headers"))
+	assert.Contains(suite.T(), resp.Header.Get("Access-Control-Allow-Methods"), "PUT")
+
+	req, _ = http.NewRequest("GET", server.URL+"/about", nil)
+	req.Header.Set("Origin", "http://content.example.com")
+
+	resp, err = http.DefaultClient.Do(req)
+	assert.Nil(suite.T(), err)
+	resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(suite.T(), "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Empty(suite.T(), resp.Header.Get("Access-Control-Allow-Credentials"))
+	assert.Contains(suite.T(), resp.Header.Get("Access-Control-Expose-Headers"), "ETag")
+}
+
+func (suite *LRSTestSuite) TestLogRequests() {
+	var buf bytes.Buffer
+	server := httptest.NewServer(lrs.LogRequests(lrs.NewLRS(), log.New(&buf, "", 0)))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/statements?verb=http%3A%2F%2Fexample.com%2Fverb")
+	assert.Nil(suite.T(), err)
+	resp.Body.Close()
+
+	assert.Regexp(suite.T(), `^GET /statements\?verb=http://example.com/verb 400 \S+\n$`, buf.String())
+}
+
+func TestLRSTestSuite(t *testing.T) {
+	suite.Run(t, new(LRSTestSuite))
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/internal/output"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutputTestSuite struct {
+	suite.Suite
+}
+
+func (suite *OutputTestSuite) statements() []statement.Statement {
+	score, _ := statement.NewScore(8, 0, 10)
+
+	stmt := statement.NewStatement(
+		statement.NewAgentWithMbox("Learner", "learner@example.com"),
+		*statement.NewVerb("http://adlnet.gov/expapi/verbs/completed", statement.LanguageMap{"de-DE": "abgeschlossen", "en-GB": "completed"}),
+		statement.NewActivity("http://example.com/activities/course"),
+	)
+	stmt.ID = utils.Ptr("fd41c918-b88b-4b20-a0a5-a4c32391aaa0")
+	stmt.Result = &statement.Result{
+		Score:      score,
+		Success:    utils.Ptr(true),
+		Extensions: &statement.Extensions{"http://example.com/ext.duration": "a\tb"},
+	}
+
+	other := statement.NewStatement(
+		statement.NewAnonymousAgentWithOpenID("http://openid.example.com/learner"),
+		*statement.NewVerb("http://adlnet.gov/expapi/verbs/attempted", statement.LanguageMap{"fr": "tenté"}),
+		statement.NewActivity("http://example.com/activities/quiz"),
+	)
+
+	return []statement.Statement{*stmt, *other}
+}
+
+func (suite *OutputTestSuite) TestParseField() {
+	for _, expr := range []string{"actor.name", `result.extensions["http://example.com/ext"]`, "context.contextActivities.parent[0].id", "verb.display.en-US", `a['b.c'][1]`} {
+		_, err := output.ParseField(expr)
+		assert.Nil(suite.T(), err, expr)
+	}
+
+	for _, expr := range []string{"", "actor..name", "actor.", "a[", "a[x]", "a[-1]", "a[0]b"} {
+		_, err := output.ParseField(expr)
+		assert.NotNil(suite.T(), err, expr)
+	}
+
+	_, err := output.ParseFormat("xml")
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *OutputTestSuite) TestFieldListFlag() {
+	var fields output.FieldList
+
+	cmd := &cobra.Command{Run: func(cmd *cobra.Command, args []string) {}}
+	cmd.Flags().Var(&fields, "fields", "")
+	cmd.SetArgs([]string{"--fields", `actor.name,result.extensions["http://example.com/a,b"]`, "--fields", "verb.id"})
+
+	assert.Nil(suite.T(), cmd.Execute())
+	assert.Equal(suite.T(), output.FieldList{"actor.name", `result.extensions["http://example.com/a,b"]`, "verb.id"}, fields)
+
+	_, err := output.ParseFields(fields)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *OutputTestSuite) TestValue() {
+	var record any
+
+	b, _ := json.Marshal(suite.statements()[0])
+	assert.Nil(suite.T(), json.Unmarshal(b, &record))
+
+	fields, err := output.ParseFields([]string{"actor.ifi", "verb.display.de-DE", `result.extensions["http://example.com/ext.duration"]`, "result.score.scaled", "context.registration", "object.id[0]"})
+	assert.Nil(suite.T(), err)
+
+	assert.Equal(suite.T(), "mbox:mailto:learner@example.com", fields[0].Value(record))
+	assert.Equal(suite.T(), "abgeschlossen", fields[1].Value(record))
+	assert.Equal(suite.T(), "a\tb", fields[2].Value(record))
+	assert.Equal(suite.T(), 0.8, fields[3].Value(record))
+	assert.Nil(suite.T(), fields[4].Value(record))
+	assert.Nil(suite.T(), fields[5].Value(record))
+}
+
+func (suite *OutputTestSuite) TestCSV() {
+	fields, err := output.ParseFields(output.StatementFields)
+	assert.Nil(suite.T(), err)
+
+	var buf bytes.Buffer
+	assert.Nil(suite.T(), output.Write(&buf, output.CSV, suite.statements(), fields))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+
+	assert.Len(suite.T(), lines, 3)
+	assert.Equal(suite.T(), "id,actor.name,actor.ifi,verb.display,object.id,object.definition.name,result.score.scaled,result.success,result.completion,context.registration,timestamp", string(lines[0]))
+	assert.Contains(suite.T(), string(lines[1]), "fd41c918-b88b-4b20-a0a5-a4c32391aaa0,Learner,mbox:mailto:learner@example.com,completed,http://example.com/activities/course,,0.8,true,,,")
+	assert.Contains(suite.T(), string(lines[2]), ",openid:http://openid.example.com/learner,tenté,http://example.com/activities/quiz,")
+}
+
+func (suite *OutputTestSuite) TestTable() {
+	fields, err := output.ParseFields([]string{"verb.display", `result.extensions["http://example.com/ext.duration"]`})
+	assert.Nil(suite.T(), err)
+
+	var buf bytes.Buffer
+	assert.Nil(suite.T(), output.Write(&buf, output.Table, suite.statements(), fields))
+
+	assert.Equal(suite.T(), "VERB.DISPLAY  RESULT.EXTENSIONS[\"HTTP://EXAMPLE.COM/EXT.DURATION\"]\ncompleted     a b\ntenté         \n", buf.String())
+}
+
+func (suite *OutputTestSuite) TestJSON() {
+	var buf bytes.Buffer
+	assert.Nil(suite.T(), output.Write(&buf, output.NDJSON, suite.statements(), nil))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(suite.T(), lines, 2)
+
+	stmt := statement.Statement{}
+	assert.Nil(suite.T(), json.Unmarshal(lines[0], &stmt))
+	assert.Equal(suite.T(), "fd41c918-b88b-4b20-a0a5-a4c32391aaa0", *stmt.ID)
+
+	// Selected fields keep their order
+	fields, err := output.ParseFields([]string{"verb.id", "actor.ifi"})
+	assert.Nil(suite.T(), err)
+
+	buf.Reset()
+	assert.Nil(suite.T(), output.Write(&buf, output.NDJSON, suite.statements(), fields))
+	assert.Equal(suite.T(), `{"verb.id":"http://adlnet.gov/expapi/verbs/completed","actor.ifi":"mbox:mailto:learner@example.com"}`, string(bytes.Split(buf.Bytes(), []byte("\n"))[0]))
+
+	// A single value is written as an object rather than a list
+	buf.Reset()
+	assert.Nil(suite.T(), output.Write(&buf, output.JSON, suite.statements()[0], nil))
+
+	stmt = statement.Statement{}
+	assert.Nil(suite.T(), json.Unmarshal(buf.Bytes(), &stmt))
+	assert.Equal(suite.T(), "fd41c918-b88b-4b20-a0a5-a4c32391aaa0", *stmt.ID)
+}
+
+func TestOutputTestSuite(t *testing.T) {
+	suite.Run(t, new(OutputTestSuite))
+}
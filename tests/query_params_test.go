@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryParamsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *QueryParamsTestSuite) TestMap() {
+	params := client.StatementQueryParams{
+		StatementID:       utils.Ptr("a"),
+		VoidedStatementId: utils.Ptr("b"),
+		Agent:             statement.NewAnonymousAgentWithMbox("mailto:bkaraceylan@gmail.com"),
+		Verb:              statement.NewVerb("http://adlnet.gov/expapi/verbs/completed", nil),
+		Activity:          statement.NewActivity("http://example.com/activity"),
+		Registeration:     utils.Ptr("ec531277-b57b-4c15-8d91-d292c5b2b8f7"),
+		RelatedActivities: utils.Ptr(true),
+		RelatedAgents:     utils.Ptr(false),
+		Until:             utils.Ptr(time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)),
+		Limit:             utils.Ptr(int64(10)),
+		Format:            utils.Ptr("ids"),
+		Ascending:         utils.Ptr(true),
+	}
+
+	assert.Equal(suite.T(), map[string]string{
+		"statementId":        "a",
+		"voidedStatementId":  "b",
+		"agent":              `{"objectType":"Agent","mbox":"mailto:bkaraceylan@gmail.com"}`,
+		"verb":               "http://adlnet.gov/expapi/verbs/completed",
+		"activity":           "http://example.com/activity",
+		"registration":       "ec531277-b57b-4c15-8d91-d292c5b2b8f7",
+		"related_activities": "true",
+		"related_agents":     "false",
+		"until":              "2022-01-01T10:00:00.000Z",
+		"limit":              "10",
+		"format":             "ids",
+		"ascending":          "true",
+	}, params.Map())
+}
+
+func TestQueryParamsTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryParamsTestSuite))
+}
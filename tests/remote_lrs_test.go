@@ -138,7 +138,7 @@ func (suite *ResourceTestSuite) TestGetStatement() {
 	assert.Equal(suite.T(), suite.Activity, *retrieved.Object.(*statement.Activity))
 	assert.Equal(suite.T(), suite.Verb, retrieved.Verb)
 	assert.NotNil(suite.T(), retrieved.Stored)
-	assert.NotEqual(suite.T(), time.Time{}, *retrieved.Stored)
+	assert.NotEqual(suite.T(), statement.Timestamp{}, *retrieved.Stored)
 
 	auth := statement.NewAgentWithAccount(
 		"Unnamed Account",
@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/replicate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const replicateStatement = `{
+	"id": "%s",
+	"actor": {"objectType": "Agent", "mbox": "mailto:learner@example.com"},
+	"verb": {"id": "%s", "display": {"en-US": "test"}},
+	"object": %s,
+	"stored": "%s",
+	"authority": {"objectType": "Agent", "mbox": "mailto:authority@example.com"}
+}`
+
+type ReplicateTestSuite struct {
+	suite.Suite
+	source *httptest.Server
+	target *httptest.Server
+	mu     sync.Mutex
+	puts   []string
+	states map[string][]byte
+}
+
+// Starts a source LRS serving a statement, then on the next page a statement voiding one it doesn't return,
+// along with a state document. onFirstPage is called with the query of the first page, if not nil.
+func newSourceLRS(onFirstPage func(q url.Values)) *httptest.Server {
+	activity := `{"objectType": "Activity", "id": "http://example.com/activity"}`
+	voided := fmt.Sprintf(replicateStatement, "00000000-0000-0000-0000-000000000001", "http://adlnet.gov/expapi/verbs/experienced", activity, "2022-01-01T00:00:00.000Z")
+	first := fmt.Sprintf(replicateStatement, "00000000-0000-0000-0000-000000000002", "http://adlnet.gov/expapi/verbs/experienced", activity, "2022-01-01T00:00:01.000Z")
+	voiding := fmt.Sprintf(replicateStatement, "00000000-0000-0000-0000-000000000003", "http://adlnet.gov/expapi/verbs/voided",
+		`{"objectType": "StatementRef", "id": "00000000-0000-0000-0000-000000000001"}`, "2022-01-01T00:00:02.000Z")
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		switch {
+		case r.URL.Path == "/statements" && q.Get("voidedStatementId") != "":
+			fmt.Fprint(w, voided)
+		case r.URL.Path == "/statements" && q.Get("more") != "":
+			fmt.Fprintf(w, `{"statements": [%s], "more": ""}`, voiding)
+		case r.URL.Path == "/statements":
+			if onFirstPage != nil {
+				onFirstPage(q)
+			}
+
+			fmt.Fprintf(w, `{"statements": [%s], "more": "/statements?more=1"}`, first)
+		case r.URL.Path == "/activities/state" && q.Get("stateId") == "":
+			fmt.Fprint(w, `["bookmark"]`)
+		case r.URL.Path == "/activities/state":
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "page-3")
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+}
+
+func (suite *ReplicateTestSuite) SetupTest() {
+	suite.puts = nil
+	suite.states = make(map[string][]byte)
+	suite.source = newSourceLRS(nil)
+
+	suite.target = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/statements":
+			suite.puts = append(suite.puts, r.URL.Query().Get("statementId"))
+		case "/activities/state":
+			b, _ := io.ReadAll(r.Body)
+			suite.states[r.URL.Query().Get("stateId")] = b
+		}
+
+		w.WriteHeader(204)
+	}))
+}
+
+func (suite *ReplicateTestSuite) TearDownTest() {
+	suite.source.Close()
+	suite.target.Close()
+}
+
+func (suite *ReplicateTestSuite) lrs(server *httptest.Server) *client.RemoteLRS {
+	lrs, err := client.NewRemoteLRS(server.URL+"/", "1.0.3", "Basic dGVzdDp0ZXN0")
+	assert.Nil(suite.T(), err)
+
+	return lrs
+}
+
+func (suite *ReplicateTestSuite) TestReplicate() {
+	checkpoint := filepath.Join(suite.T().TempDir(), "checkpoint.json")
+
+	r := replicate.NewReplicator(suite.lrs(suite.source), suite.lrs(suite.target), &replicate.Options{
+		CheckpointFile: checkpoint,
+		Documents:      true,
+	})
+
+	stats, err := r.Run()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, stats.Statements)
+	assert.Equal(suite.T(), 1, stats.Voided)
+	assert.Equal(suite.T(), 1, stats.Documents)
+	assert.Equal(suite.T(), []string{
+		"00000000-0000-0000-0000-000000000002",
+		"00000000-0000-0000-0000-000000000001",
+		"00000000-0000-0000-0000-000000000003",
+	}, suite.puts)
+	assert.Equal(suite.T(), []byte("page-3"), suite.states["bookmark"])
+
+	saved, err := replicate.LoadCheckpoint(checkpoint)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), time.Date(2022, 1, 1, 0, 0, 2, 0, time.UTC), saved.Since.UTC())
+	assert.Equal(suite.T(), []string{"00000000-0000-0000-0000-000000000003"}, saved.IDs)
+
+	// Resuming skips everything that has already been replicated
+	stats, err = replicate.NewReplicator(suite.lrs(suite.source), suite.lrs(suite.target), &replicate.Options{
+		CheckpointFile: checkpoint,
+	}).Run()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0, stats.Statements)
+	assert.Equal(suite.T(), 2, stats.Skipped)
+	assert.Equal(suite.T(), 3, len(suite.puts))
+}
+
+func (suite *ReplicateTestSuite) TestCheckpoint() {
+	c := replicate.Checkpoint{}
+	t := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.False(suite.T(), c.Contains("a", t))
+
+	c.Advance("a", t)
+	c.Advance("b", t)
+
+	assert.True(suite.T(), c.Contains("a", t))
+	assert.True(suite.T(), c.Contains("b", t))
+	assert.False(suite.T(), c.Contains("c", t))
+	assert.True(suite.T(), c.Contains("c", t.Add(-time.Second)))
+
+	c.Advance("c", t.Add(time.Second))
+
+	assert.Equal(suite.T(), []string{"c"}, c.IDs)
+
+	path := filepath.Join(suite.T().TempDir(), "checkpoint.json")
+
+	assert.Nil(suite.T(), c.Save(path))
+
+	loaded, err := replicate.LoadCheckpoint(path)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), c.IDs, loaded.IDs)
+	assert.True(suite.T(), c.Since.Equal(*loaded.Since))
+}
+
+func TestReplicateTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplicateTestSuite))
+}
@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/lrs"
+	"github.com/burakkaraceylan/xapi-go/pkg/report"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+}
+
+const reportStatements = `[
+	{
+		"actor": {"name": "Ada", "mbox": "mailto:ada@example.com"},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/attempted"},
+		"object": {"id": "http://example.com/module-1", "definition": {"name": {"en-US": "Module 1"}}},
+		"timestamp": "2023-01-01T10:00:00Z"
+	},
+	{
+		"actor": {"name": "Ada", "mbox": "mailto:ada@example.com"},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/failed"},
+		"object": {"id": "http://example.com/module-1"},
+		"result": {"score": {"raw": 4, "min": 0, "max": 10}, "duration": "PT10M"},
+		"timestamp": "2023-01-01T10:10:00Z"
+	},
+	{
+		"actor": {"name": "Ada", "mbox": "mailto:ada@example.com"},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/passed"},
+		"object": {"id": "http://example.com/module-1"},
+		"result": {"score": {"scaled": 0.9}, "duration": "PT5M", "completion": true},
+		"timestamp": "2023-01-02T09:00:00Z"
+	},
+	{
+		"actor": {"account": {"homePage": "http://lms.example.com", "name": "bob"}},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/experienced"},
+		"object": {"id": "http://example.com/module-1"},
+		"result": {"success": true},
+		"timestamp": "2023-01-03T09:00:00Z"
+	},
+	{
+		"actor": {"account": {"homePage": "http://lms.example.com", "name": "bob"}},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/voided"},
+		"object": {"objectType": "StatementRef", "id": "fd41c918-b88b-4b20-a0a5-a4c32391aaa0"},
+		"timestamp": "2023-01-03T09:00:00Z"
+	}
+]`
+
+func (suite *ReportTestSuite) statements() []statement.Statement {
+	var stmts []statement.Statement
+	assert.Nil(suite.T(), json.Unmarshal([]byte(reportStatements), &stmts))
+
+	return stmts
+}
+
+func (suite *ReportTestSuite) TestByAgentActivity() {
+	a := report.NewAggregator(report.ByAgentActivity)
+
+	for _, stmt := range suite.statements() {
+		a.Add(stmt)
+	}
+
+	summaries := a.Summaries()
+	assert.Len(suite.T(), summaries, 2)
+
+	bob := summaries[0]
+	assert.Equal(suite.T(), "account:http://lms.example.com|bob", bob.Agent)
+	assert.Equal(suite.T(), 1, bob.Statements)
+	assert.False(suite.T(), bob.Completed)
+	assert.Equal(suite.T(), 1.0, *bob.PassRate)
+	assert.Nil(suite.T(), bob.BestScore)
+	assert.Nil(suite.T(), bob.AverageScore)
+
+	ada := summaries[1]
+	assert.Equal(suite.T(), "mbox:mailto:ada@example.com", ada.Agent)
+	assert.Equal(suite.T(), "Ada", ada.AgentName)
+	assert.Equal(suite.T(), "Module 1", ada.ActivityName)
+	assert.Equal(suite.T(), 1, ada.Learners)
+	assert.Equal(suite.T(), 3, ada.Statements)
+	assert.True(suite.T(), ada.Completed)
+	assert.Equal(suite.T(), 1, ada.Completions)
+	assert.Equal(suite.T(), time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC), ada.FirstCompletion.Time)
+	assert.Equal(suite.T(), 1, ada.Passed)
+	assert.Equal(suite.T(), 1, ada.Failed)
+	assert.Equal(suite.T(), 0.5, *ada.PassRate)
+	assert.Equal(suite.T(), 2, ada.Scores)
+	assert.Equal(suite.T(), 0.9, *ada.BestScore)
+	assert.Equal(suite.T(), 0.65, *ada.AverageScore)
+	assert.Equal(suite.T(), 15*time.Minute, ada.Duration.ToTimeDuration())
+	assert.Equal(suite.T(), time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), ada.FirstAttempt.Time)
+	assert.Equal(suite.T(), time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC), ada.LastAttempt.Time)
+}
+
+func (suite *ReportTestSuite) TestByActivity() {
+	a := report.NewAggregator(report.ByActivity)
+
+	for _, stmt := range suite.statements() {
+		a.Add(stmt)
+	}
+
+	summaries := a.Summaries()
+	assert.Len(suite.T(), summaries, 1)
+
+	module := summaries[0]
+	assert.Empty(suite.T(), module.Agent)
+	assert.Equal(suite.T(), 2, module.Learners)
+	assert.Equal(suite.T(), 4, module.Statements)
+	assert.Equal(suite.T(), 2, module.Passed)
+	assert.Equal(suite.T(), 0.667, *module.PassRate)
+	assert.Equal(suite.T(), time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC), module.LastAttempt.Time)
+}
+
+func (suite *ReportTestSuite) TestOutOfBoundsScore() {
+	var stmt statement.Statement
+	assert.Nil(suite.T(), json.Unmarshal([]byte(`{
+		"actor": {"mbox": "mailto:ada@example.com"},
+		"verb": {"id": "http://adlnet.gov/expapi/verbs/scored"},
+		"object": {"id": "http://example.com/module-1"},
+		"result": {"score": {"raw": 12, "min": 0, "max": 10}}
+	}`), &stmt))
+
+	a := report.NewAggregator(report.ByActivity)
+	a.Add(stmt)
+
+	module := a.Summaries()[0]
+	assert.Equal(suite.T(), 0, module.Scores)
+	assert.Nil(suite.T(), module.BestScore)
+	assert.Nil(suite.T(), module.AverageScore)
+}
+
+func (suite *ReportTestSuite) TestQuery() {
+	server := httptest.NewServer(lrs.NewLRS(&lrs.Options{PageSize: 2}))
+	defer server.Close()
+
+	remote, err := client.NewRemoteLRS(server.URL+"/", lrs.Version, "test", "test")
+	assert.Nil(suite.T(), err)
+
+	_, _, err = remote.SaveStatements(suite.statements()[:4])
+	assert.Nil(suite.T(), err)
+
+	a := report.NewAggregator(report.ByAgentActivity)
+	count, err := a.Query(remote, &client.StatementQueryParams{Agent: statement.NewAnonymousAgentWithMbox("ada@example.com")})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 3, count)
+
+	summaries := a.Summaries()
+	assert.Len(suite.T(), summaries, 1)
+	assert.Equal(suite.T(), 3, summaries[0].Statements)
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
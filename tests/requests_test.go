@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/burakkaraceylan/xapi-go/pkg/client"
@@ -81,6 +82,14 @@ func (suite *RequestsTestSuite) TestResponse() {
 	assert.Equal(suite.T(), response.String(), expected)
 }
 
+func (suite *RequestsTestSuite) TestResponseErr() {
+	resp := client.Response{Status: 400, Response: &http.Response{Body: io.NopCloser(strings.NewReader("invalid statement"))}}
+	assert.EqualError(suite.T(), resp.Err(), "lrs responded with 400: invalid statement")
+
+	resp = client.Response{Status: 404, Response: &http.Response{Body: http.NoBody}}
+	assert.EqualError(suite.T(), resp.Err(), "lrs responded with 404")
+}
+
 func TestRequestsTestSuite(t *testing.T) {
 	suite.Run(t, new(RequestsTestSuite))
 }
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScoreTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScoreTestSuite) TestNewScore() {
+	score, err := statement.NewScore(85, 0, 100)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0.85, *score.Scaled)
+	assert.Equal(suite.T(), 85.0, *score.Raw)
+
+	score, err = statement.NewScore(75, 50, 100)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 0.5, *score.Scaled)
+
+	_, err = statement.NewScore(120, 0, 100)
+	assert.EqualError(suite.T(), err, "invalid score: raw 120 is greater than max 100")
+
+	_, err = statement.NewScore(5, 10, 10)
+	assert.NotNil(suite.T(), err)
+
+	score, err = statement.NewScaledScore(-0.5)
+
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), score.Raw)
+
+	_, err = statement.NewScaledScore(1.5)
+	assert.EqualError(suite.T(), err, "invalid score: scaled 1.5 is not between -1 and 1")
+
+	_, err = statement.NewScore(math.NaN(), 0, 100)
+	assert.EqualError(suite.T(), err, "invalid score: raw is not a number")
+
+	_, err = statement.NewScaledScore(math.NaN())
+	assert.EqualError(suite.T(), err, "invalid score: scaled is not a number")
+}
+
+func (suite *ScoreTestSuite) TestValidate() {
+	assert.Nil(suite.T(), statement.Score{}.Validate())
+	assert.Nil(suite.T(), statement.Score{Raw: utils.Ptr(-3.0)}.Validate())
+
+	err := statement.Score{Raw: utils.Ptr(-1.0), Min: utils.Ptr(0.0), Scaled: utils.Ptr(-2.0)}.Validate()
+	assert.EqualError(suite.T(), err, "invalid score: scaled -2 is not between -1 and 1, raw -1 is less than min 0")
+
+	err = statement.Score{Raw: utils.Ptr(5.0), Min: utils.Ptr(math.NaN()), Max: utils.Ptr(10.0)}.Validate()
+	assert.EqualError(suite.T(), err, "invalid score: min is not a number")
+}
+
+func (suite *ScoreTestSuite) TestJSON() {
+	score, err := statement.NewScore(0.1, 0, 1)
+	assert.Nil(suite.T(), err)
+
+	b, err := json.Marshal(score)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), `{"scaled":0.1,"raw":0.1,"min":0,"max":1}`, string(b))
+}
+
+func TestScoreTestSuite(t *testing.T) {
+	suite.Run(t, new(ScoreTestSuite))
+}
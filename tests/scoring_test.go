@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScoringTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScoringTestSuite) check(ad *statement.ActivityDefinition, response string) bool {
+	correct, err := ad.CheckResponse(response)
+	assert.Nil(suite.T(), err, response)
+
+	return correct
+}
+
+func (suite *ScoringTestSuite) TestSimpleTypes() {
+	ad := statement.NewTrueFalseInteraction(false)
+
+	assert.True(suite.T(), suite.check(ad, "false"))
+	assert.False(suite.T(), suite.check(ad, "true"))
+
+	ad = statement.NewOtherInteraction([]string{"(35.937432,-86.868896)"})
+
+	assert.True(suite.T(), suite.check(ad, "(35.937432,-86.868896)"))
+	assert.False(suite.T(), suite.check(ad, "(0,0)"))
+}
+
+func (suite *ScoringTestSuite) TestChoiceAndSequencing() {
+	components := []statement.InteractionComponent{
+		*statement.NewInteractionComponent("a"),
+		*statement.NewInteractionComponent("b"),
+		*statement.NewInteractionComponent("c"),
+	}
+
+	ad, _ := statement.NewChoiceInteraction(components, []string{"a", "c"})
+
+	assert.True(suite.T(), suite.check(ad, "c[,]a"))
+	assert.False(suite.T(), suite.check(ad, "a"))
+	assert.False(suite.T(), suite.check(ad, "a[,]b[,]c"))
+
+	// Any of the patterns can match
+	ad.CorrectResponsesPattern = append(ad.CorrectResponsesPattern, "b")
+	assert.True(suite.T(), suite.check(ad, "b"))
+
+	ad, _ = statement.NewSequencingInteraction(components, []string{"c", "a", "b"})
+
+	assert.True(suite.T(), suite.check(ad, "c[,]a[,]b"))
+	assert.False(suite.T(), suite.check(ad, "a[,]b[,]c"))
+}
+
+func (suite *ScoringTestSuite) TestMatching() {
+	components := []statement.InteractionComponent{*statement.NewInteractionComponent("a"), *statement.NewInteractionComponent("b")}
+
+	ad, _ := statement.NewMatchingInteraction(components, components, [][2]string{{"a", "b"}, {"b", "a"}})
+
+	assert.True(suite.T(), suite.check(ad, "b[.]a[,]a[.]b"))
+	assert.False(suite.T(), suite.check(ad, "a[.]a[,]b[.]b"))
+}
+
+func (suite *ScoringTestSuite) TestNumeric() {
+	ad, _ := statement.NewNumericInteraction(utils.Ptr(1.0), utils.Ptr(5.0))
+
+	assert.True(suite.T(), suite.check(ad, "1"))
+	assert.True(suite.T(), suite.check(ad, "4.5"))
+	assert.False(suite.T(), suite.check(ad, "5.1"))
+	assert.False(suite.T(), suite.check(ad, "four"))
+
+	ad, _ = statement.NewNumericInteraction(nil, utils.Ptr(0.0))
+
+	assert.True(suite.T(), suite.check(ad, "-100"))
+	assert.False(suite.T(), suite.check(ad, "1"))
+
+	ad.CorrectResponsesPattern = []string{"4"}
+	assert.True(suite.T(), suite.check(ad, "4.0"))
+
+	ad.CorrectResponsesPattern = []string{"x[:]5"}
+	_, err := ad.CheckResponse("4")
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *ScoringTestSuite) TestFillIn() {
+	ad := statement.NewFillInInteraction([]string{"Red", "Blue"})
+
+	assert.True(suite.T(), suite.check(ad, "red[,]BLUE"))
+	assert.False(suite.T(), suite.check(ad, "blue[,]red"))
+
+	ad = statement.NewFillInInteraction([]string{"Red", "Blue"}, &statement.InteractionOptions{CaseMatters: utils.Ptr(true), OrderMatters: utils.Ptr(false)})
+
+	assert.True(suite.T(), suite.check(ad, "Blue[,]Red"))
+	assert.False(suite.T(), suite.check(ad, "blue[,]red"))
+
+	ad = statement.NewLongFillInInteraction([]string{"{lang=en}To store and provide access to learning experiences"})
+
+	assert.True(suite.T(), suite.check(ad, "{lang=en-US}to store and provide access to learning experiences"))
+}
+
+func (suite *ScoringTestSuite) TestPerformance() {
+	components := []statement.InteractionComponent{*statement.NewInteractionComponent("pong"), *statement.NewInteractionComponent("dg")}
+
+	ad, _ := statement.NewPerformanceInteraction(components, [][2]string{
+		{"pong", statement.NumericRange(utils.Ptr(1.0), utils.Ptr(4.0))},
+		{"dg", "10"},
+	})
+
+	assert.True(suite.T(), suite.check(ad, "pong[.]2[,]dg[.]10"))
+	assert.False(suite.T(), suite.check(ad, "dg[.]10[,]pong[.]2"))
+	assert.False(suite.T(), suite.check(ad, "pong[.]5[,]dg[.]10"))
+
+	ad.CorrectResponsesPattern[0] = "{order_matters=false}" + ad.CorrectResponsesPattern[0]
+	assert.True(suite.T(), suite.check(ad, "dg[.]10[,]pong[.]2"))
+}
+
+func (suite *ScoringTestSuite) TestStatement() {
+	ad := statement.NewTrueFalseInteraction(true)
+	stmt := statement.NewStatement(
+		statement.NewAgentWithMbox("Burak Karaceylan", "mailto:bkaraceylan@gmail.com"),
+		*statement.NewVerb("http://adlnet.gov/expapi/verbs/answered", statement.LanguageMap{"en-US": "answered"}),
+		statement.NewActivityWithDefiniton("http://github.com/bkaraceylan/xapi-go/Test/Unit/0", ad),
+		&statement.StatementOptions{Result: &statement.Result{Response: utils.Ptr("true")}},
+	)
+
+	correct, err := stmt.CheckResponse()
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), correct)
+
+	_, err = (&statement.ActivityDefinition{}).CheckResponse("true")
+	assert.NotNil(suite.T(), err)
+
+	stmt.Result = nil
+	_, err = stmt.CheckResponse()
+	assert.NotNil(suite.T(), err)
+}
+
+func TestScoringTestSuite(t *testing.T) {
+	suite.Run(t, new(ScoringTestSuite))
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/scrub"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScrubTestSuite struct {
+	suite.Suite
+}
+
+// HMAC-SHA1 keyed with "secret" of the SHA1 of mailto:bkaraceylan@gmail.com
+const testScrubbedMbox = "ef7831bd2dd4cfba5b928ca3cc1270f0626c3a29"
+
+func (suite *ScrubTestSuite) newStatement() *statement.Statement {
+	team := statement.NewGroup("Team")
+	team.AddMember(*statement.NewAgentWithSHA1("Member", testMboxSHA1))
+	team.AddMember(*statement.NewAgentWithAccount("Other", statement.NewAccount("http://example.com", "other")))
+
+	sub := statement.NewSubStatement(
+		statement.NewAgentWithMbox("Burak Karaceylan", "mailto:bkaraceylan@gmail.com"),
+		*statement.NewVerb("http://adlnet.gov/expapi/verbs/mentored", statement.LanguageMap{"en-US": "mentored"}),
+		statement.NewAgentWithAccount("Student", statement.NewAccount("http://example.com", "student")),
+	)
+
+	return statement.NewStatement(
+		statement.NewAgentWithMbox("Burak Karaceylan", "mailto:bkaraceylan@gmail.com"),
+		*statement.NewVerb("http://adlnet.gov/expapi/verbs/planned", statement.LanguageMap{"en-US": "planned"}),
+		sub,
+		&statement.StatementOptions{
+			Authority: statement.NewAgentWithAccount("LRS", statement.NewAccount("http://example.com", "lrs")),
+			Context: &statement.Context{
+				Instructor: statement.NewAgentWithMbox("Instructor", "mailto:instructor@example.com"),
+				Team:       team,
+				Extensions: &statement.Extensions{"http://example.com/ext/ip": "10.0.0.1", "http://example.com/ext/course": "101"},
+			},
+			Result: &statement.Result{
+				Response:   utils.Ptr("answer"),
+				Extensions: &statement.Extensions{"http://example.com/ext/ip": "10.0.0.1"},
+			},
+		},
+	)
+}
+
+func (suite *ScrubTestSuite) TestScrub() {
+	stmt := suite.newStatement()
+
+	scrubber := scrub.NewScrubber(&scrub.Options{
+		DropNames:        true,
+		MboxKey:          []byte("secret"),
+		AccountNames:     map[string]string{"student": "learner-1"},
+		RedactExtensions: []string{"http://example.com/ext/ip"},
+	})
+
+	scrubbed, err := scrubber.Scrub(*stmt)
+	assert.Nil(suite.T(), err)
+
+	actor := scrubbed.Actor.(*statement.Agent)
+
+	assert.Nil(suite.T(), actor.Name)
+	assert.Nil(suite.T(), actor.Mbox)
+	assert.Equal(suite.T(), testScrubbedMbox, *actor.MboxSHA1Sum)
+
+	// The hashed mailbox and the mailbox hash to the same value
+	team := scrubbed.Context.Team
+
+	assert.Nil(suite.T(), team.Name)
+	assert.Equal(suite.T(), testScrubbedMbox, *team.Members[0].MboxSHA1Sum)
+	assert.Equal(suite.T(), "other", team.Members[1].Account.Name)
+	assert.Nil(suite.T(), team.Members[1].Name)
+
+	instructor := scrubbed.Context.Instructor.(*statement.Agent)
+
+	assert.Nil(suite.T(), instructor.Mbox)
+	assert.NotNil(suite.T(), instructor.MboxSHA1Sum)
+
+	assert.Equal(suite.T(), "lrs", scrubbed.Authority.(*statement.Agent).Account.Name)
+
+	sub := scrubbed.Object.(*statement.SubStatement)
+
+	assert.Equal(suite.T(), testScrubbedMbox, *sub.Actor.(*statement.Agent).MboxSHA1Sum)
+	assert.Equal(suite.T(), "learner-1", sub.Object.(*statement.Agent).Account.Name)
+
+	assert.Equal(suite.T(), statement.Extensions{"http://example.com/ext/course": "101"}, *scrubbed.Context.Extensions)
+	assert.Nil(suite.T(), scrubbed.Result.Extensions)
+	assert.Equal(suite.T(), "answer", *scrubbed.Result.Response)
+
+	// The original is left untouched
+	assert.Equal(suite.T(), "mailto:bkaraceylan@gmail.com", *stmt.Actor.(*statement.Agent).Mbox)
+	assert.Equal(suite.T(), "Team", *stmt.Context.Team.Name)
+	assert.Equal(suite.T(), testMboxSHA1, *stmt.Context.Team.Members[0].MboxSHA1Sum)
+	assert.Equal(suite.T(), 2, len(*stmt.Context.Extensions))
+	assert.Equal(suite.T(), "student", stmt.Object.(*statement.SubStatement).Object.(*statement.Agent).Account.Name)
+}
+
+func (suite *ScrubTestSuite) TestHashUnmappedAccounts() {
+	stmt := suite.newStatement()
+
+	_, err := scrub.NewScrubber(&scrub.Options{HashUnmappedAccounts: true}).Scrub(*stmt)
+	assert.NotNil(suite.T(), err)
+
+	scrubbed, err := scrub.NewScrubber(&scrub.Options{MboxKey: []byte("secret"), HashUnmappedAccounts: true}).Scrub(*stmt)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 40, len(scrubbed.Authority.(*statement.Agent).Account.Name))
+	assert.Equal(suite.T(), "LRS", *scrubbed.Authority.(*statement.Agent).Name)
+}
+
+func (suite *ScrubTestSuite) TestOpenID() {
+	stmt := suite.newStatement()
+	stmt.Actor = statement.NewAgentWithOpenID("Learner", "http://openid.example.com/learner")
+	stmt.Object = &statement.Group{ObjectType: "Group", OpenID: utils.Ptr("http://openid.example.com/team"), Members: []statement.Agent{*statement.NewAnonymousAgentWithOpenID("http://openid.example.com/learner")}}
+
+	scrubbed, err := scrub.NewScrubber(&scrub.Options{MboxKey: []byte("secret")}).Scrub(*stmt)
+	assert.Nil(suite.T(), err)
+
+	actor := scrubbed.Actor.(*statement.Agent)
+	group := scrubbed.Object.(*statement.Group)
+
+	assert.Regexp(suite.T(), `^urn:hmac-sha1:[0-9a-f]{40}$`, *actor.OpenID)
+	assert.Regexp(suite.T(), `^urn:hmac-sha1:[0-9a-f]{40}$`, *group.OpenID)
+	assert.NotEqual(suite.T(), *actor.OpenID, *group.OpenID)
+	// The same OpenID maps to the same value wherever it appears
+	assert.Equal(suite.T(), *actor.OpenID, *group.Members[0].OpenID)
+	assert.Nil(suite.T(), scrubbed.Validate())
+
+	// Without a key OpenIDs are kept
+	scrubbed, err = scrub.NewScrubber().Scrub(*stmt)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "http://openid.example.com/learner", *scrubbed.Actor.(*statement.Agent).OpenID)
+}
+
+func (suite *ScrubTestSuite) TestInvalidMbox() {
+	stmt := suite.newStatement()
+	stmt.Actor = statement.NewAgentWithMbox("Burak Karaceylan", "invalid")
+
+	_, err := scrub.NewScrubber(&scrub.Options{MboxKey: []byte("secret")}).Scrub(*stmt)
+	assert.NotNil(suite.T(), err)
+
+	// Without a key mailboxes are kept
+	_, err = scrub.NewScrubber().Scrub(*stmt)
+	assert.Nil(suite.T(), err)
+}
+
+func TestScrubTestSuite(t *testing.T) {
+	suite.Run(t, new(ScrubTestSuite))
+}
@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SignatureTestSuite struct {
+	suite.Suite
+	Key   *rsa.PrivateKey
+	Cert  *x509.Certificate
+	Roots *x509.CertPool
+}
+
+func (suite *SignatureTestSuite) SetupSuite() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		panic(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "xapi-go test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+
+	if err != nil {
+		panic(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		panic(err)
+	}
+
+	suite.Key = key
+	suite.Cert = cert
+	suite.Roots = x509.NewCertPool()
+	suite.Roots.AddCert(cert)
+}
+
+func (suite *SignatureTestSuite) newStatement() *statement.Statement {
+	return statement.NewStatement(
+		statement.NewAgentWithMbox("Burak Karaceylan", "mailto:bkaraceylan@gmail.com"),
+		*statement.NewVerb("http://adlnet.gov/expapi/verbs/completed", statement.LanguageMap{"en-US": "completed"}),
+		statement.NewActivity("http://github.com/bkaraceylan/xapi-go/Test/Unit/0"),
+		&statement.StatementOptions{ID: utils.Ptr("6f3c8b0e-3f4e-4d3a-9a59-1b1a5d0d3c11")},
+	)
+}
+
+func (suite *SignatureTestSuite) TestSignAndVerify() {
+	for _, alg := range []statement.SigningAlgorithm{statement.RS256, statement.RS384, statement.RS512} {
+		stmt := suite.newStatement()
+
+		err := statement.SignStatement(stmt, suite.Key, []*x509.Certificate{suite.Cert}, alg)
+
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), 1, len(stmt.Attachments))
+		assert.Equal(suite.T(), statement.AttachmentUsageSignature, stmt.Attachments[0].UsageType)
+		assert.Equal(suite.T(), "application/octet-stream", stmt.Attachments[0].ContentType)
+		assert.Equal(suite.T(), int64(len(stmt.Attachments[0].Content)), stmt.Attachments[0].Length)
+
+		assert.Nil(suite.T(), statement.VerifyStatement(stmt, &statement.VerifyOptions{Roots: suite.Roots}))
+	}
+}
+
+func (suite *SignatureTestSuite) TestVerifyStoredStatement() {
+	stmt := suite.newStatement()
+	assert.Nil(suite.T(), statement.SignStatement(stmt, suite.Key, []*x509.Certificate{suite.Cert}))
+
+	// Simulate the LRS storing the statement
+	b, err := json.Marshal(stmt)
+	assert.Nil(suite.T(), err)
+
+	stored := statement.Statement{}
+	assert.Nil(suite.T(), json.Unmarshal(b, &stored))
+
+	stored.Attachments[0].Content = stmt.Attachments[0].Content
+	stored.Stored = statement.NewTimestamp(time.Now())
+	stored.Version = utils.Ptr("1.0.0")
+	stored.Authority = statement.NewAnonymousAgentWithMbox("mailto:lrs@example.com")
+
+	assert.Nil(suite.T(), statement.VerifyStatement(&stored, &statement.VerifyOptions{Roots: suite.Roots}))
+}
+
+func (suite *SignatureTestSuite) TestVerifyFailures() {
+	assert.ErrorIs(suite.T(), statement.VerifyStatement(suite.newStatement()), statement.ErrNotSigned)
+
+	stmt := suite.newStatement()
+	assert.Nil(suite.T(), statement.SignStatement(stmt, suite.Key, nil))
+
+	assert.ErrorIs(suite.T(), statement.VerifyStatement(stmt), statement.ErrMissingCertificate)
+	assert.Nil(suite.T(), statement.VerifyStatement(stmt, &statement.VerifyOptions{PublicKey: &suite.Key.PublicKey}))
+
+	stmt = suite.newStatement()
+	assert.Nil(suite.T(), statement.SignStatement(stmt, suite.Key, []*x509.Certificate{suite.Cert}))
+
+	// Untrusted certificate
+	assert.ErrorIs(suite.T(), statement.VerifyStatement(stmt, &statement.VerifyOptions{Roots: x509.NewCertPool()}), statement.ErrInvalidSignature)
+
+	// Modified statement
+	stmt.Verb.ID = "http://adlnet.gov/expapi/verbs/failed"
+	assert.ErrorIs(suite.T(), statement.VerifyStatement(stmt, &statement.VerifyOptions{Roots: suite.Roots}), statement.ErrSignatureMismatch)
+
+	// Tampered signature
+	stmt = suite.newStatement()
+	assert.Nil(suite.T(), statement.SignStatement(stmt, suite.Key, []*x509.Certificate{suite.Cert}))
+	stmt.Attachments[0].Content[len(stmt.Attachments[0].Content)-2] ^= 1
+	assert.ErrorIs(suite.T(), statement.VerifyStatement(stmt, &statement.VerifyOptions{Roots: suite.Roots}), statement.ErrInvalidSignature)
+
+	assert.ErrorIs(suite.T(), statement.SignStatement(suite.newStatement(), suite.Key, nil, "HS256"), statement.ErrUnsupportedAlg)
+}
+
+func (suite *SignatureTestSuite) TestSaveSignedStatement() {
+	stmt := suite.newStatement()
+	assert.Nil(suite.T(), statement.SignStatement(stmt, suite.Key, []*x509.Certificate{suite.Cert}))
+
+	var parts []string
+	var hashes []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), "multipart/mixed", mediaType)
+
+		reader := multipart.NewReader(r.Body, params["boundary"])
+
+		for {
+			part, err := reader.NextPart()
+
+			if err != nil {
+				break
+			}
+
+			b, _ := io.ReadAll(part)
+			parts = append(parts, string(b))
+			hashes = append(hashes, part.Header.Get("X-Experience-API-Hash"))
+		}
+
+		w.WriteHeader(204)
+	}))
+	defer server.Close()
+
+	lrs, err := client.NewRemoteLRS(server.URL+"/", "1.0.3", "Basic dGVzdDp0ZXN0")
+	assert.Nil(suite.T(), err)
+
+	_, resp, err := lrs.SaveStatement(*stmt)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 204, resp.Status)
+
+	assert.Equal(suite.T(), 2, len(parts))
+	assert.Contains(suite.T(), parts[0], `"sha2":"`+stmt.Attachments[0].SHA2+`"`)
+	assert.Equal(suite.T(), string(stmt.Attachments[0].Content), parts[1])
+	assert.Equal(suite.T(), stmt.Attachments[0].SHA2, hashes[1])
+}
+
+func TestSignatureTestSuite(t *testing.T) {
+	suite.Run(t, new(SignatureTestSuite))
+}
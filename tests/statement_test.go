@@ -1,8 +1,8 @@
 package tests
 
 import (
+	"encoding/json"
 	"testing"
-	"time"
 
 	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
 	"github.com/burakkaraceylan/xapi-go/pkg/utils"
@@ -17,8 +17,8 @@ type StatementTestSuite struct {
 	Object      statement.IObject
 	Result      statement.Result
 	Context     statement.Context
-	Timestamp   time.Time
-	Stored      time.Time
+	Timestamp   statement.Timestamp
+	Stored      statement.Timestamp
 	Authority   statement.IActor
 	Version     string
 	Attachments []statement.Attachment
@@ -48,8 +48,8 @@ func (suite *StatementTestSuite) SetupSuite() {
 		Registration: utils.Ptr("test"),
 	}
 
-	suite.Timestamp = time.Time{}
-	suite.Stored = time.Time{}
+	suite.Timestamp = statement.Timestamp{}
+	suite.Stored = statement.Timestamp{}
 	suite.Authority = statement.NewAnonymousAgentWithMbox("mailto:bkaraceylan@gmail.com")
 	suite.Version = "1.0.0"
 	suite.Attachments = []statement.Attachment{}
@@ -67,8 +67,8 @@ func (suite *StatementTestSuite) TestNoOptions() {
 	assert.IsType(suite.T(), suite.Object, stmt.Object)
 	assert.IsType(suite.T(), &statement.Result{}, stmt.Result)
 	assert.IsType(suite.T(), &statement.Context{}, stmt.Context)
-	assert.IsType(suite.T(), &time.Time{}, stmt.Timestamp)
-	assert.IsType(suite.T(), &time.Time{}, stmt.Stored)
+	assert.IsType(suite.T(), &statement.Timestamp{}, stmt.Timestamp)
+	assert.IsType(suite.T(), &statement.Timestamp{}, stmt.Stored)
 	assert.IsType(suite.T(), nil, stmt.Authority)
 	assert.IsType(suite.T(), strPtr, stmt.Version)
 	assert.IsType(suite.T(), []statement.Attachment{}, stmt.Attachments)
@@ -104,6 +104,39 @@ func (suite *StatementTestSuite) TestWithOptions() {
 	assert.Equal(suite.T(), suite.Attachments, stmt.Attachments)
 }
 
+func (suite *StatementTestSuite) TestAgentObject() {
+	mbox := "mailto:ada@example.com"
+	agent := &statement.Agent{Mbox: &mbox}
+
+	stmts := []*statement.Statement{
+		statement.NewStatement(agent, suite.Verb, agent),
+		statement.NewStatement(agent, suite.Verb, *agent),
+		statement.NewStatement(agent, suite.Verb, statement.NewSubStatement(agent, suite.Verb, agent)),
+	}
+
+	for _, stmt := range stmts {
+		b, err := json.Marshal(stmt)
+		assert.Nil(suite.T(), err)
+
+		// The object type is only set on the agent object
+		assert.Contains(suite.T(), string(b), `"actor":{"mbox":"mailto:ada@example.com"}`)
+		assert.Contains(suite.T(), string(b), `"object":{"objectType":"Agent","mbox":"mailto:ada@example.com"}`)
+
+		var parsed statement.Statement
+		assert.Nil(suite.T(), json.Unmarshal(b, &parsed))
+
+		if sub, ok := parsed.Object.(*statement.SubStatement); ok {
+			assert.Equal(suite.T(), "SubStatement", sub.ObjectType)
+			assert.IsType(suite.T(), &statement.Agent{}, sub.Object, string(b))
+		} else {
+			assert.IsType(suite.T(), &statement.Agent{}, parsed.Object, string(b))
+		}
+	}
+
+	// The agent isn't modified
+	assert.Empty(suite.T(), agent.ObjectType)
+}
+
 func TestStatementTestSuite(t *testing.T) {
 	suite.Run(t, new(StatementTestSuite))
 }
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/internal/cmd"
+	"github.com/burakkaraceylan/xapi-go/internal/config"
+	"github.com/burakkaraceylan/xapi-go/pkg/lrs"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const cmdStatement = `{
+	"actor": {"objectType": "Agent", "mbox": "mailto:ada@example.com"},
+	"verb": {"id": "http://adlnet.gov/expapi/verbs/experienced", "display": {"en-US": "experienced"}},
+	"object": {"objectType": "Activity", "id": "http://example.com/activities/%d"}
+}`
+
+type StatementsCmdTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+}
+
+func (suite *StatementsCmdTestSuite) SetupTest() {
+	suite.server = httptest.NewServer(lrs.NewLRS(&lrs.Options{PageSize: 2, Username: "test", Password: "test"}))
+
+	suite.T().Setenv(config.EnvConfig, filepath.Join(suite.T().TempDir(), "config.yaml"))
+}
+
+func (suite *StatementsCmdTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+// Runs a command against the test LRS with the input and returns what it printed on stdout
+func (suite *StatementsCmdTestSuite) run(input string, args ...string) (string, error) {
+	var out bytes.Buffer
+
+	args = append(args, "--endpoint", suite.server.URL+"/", "--version", lrs.Version, "--username", "test", "--password", "test")
+	err := cmd.Run(args, strings.NewReader(input), &out, io.Discard)
+
+	return out.String(), err
+}
+
+// Runs statements query and returns the statements it printed
+func (suite *StatementsCmdTestSuite) query(args ...string) []statement.Statement {
+	out, err := suite.run("", append([]string{"statements", "query"}, args...)...)
+	assert.Nil(suite.T(), err)
+
+	var statements []statement.Statement
+	assert.Nil(suite.T(), json.Unmarshal([]byte(out), &statements))
+
+	return statements
+}
+
+func (suite *StatementsCmdTestSuite) TestPost() {
+	out, err := suite.run(fmt.Sprintf(cmdStatement, 1), "statements", "post")
+
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), strings.Fields(out), 1)
+
+	out, err = suite.run(fmt.Sprintf("[%s, %s]", fmt.Sprintf(cmdStatement, 2), fmt.Sprintf(cmdStatement, 3)), "statements", "post")
+
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), strings.Fields(out), 2)
+
+	_, err = suite.run("{", "statements", "post")
+
+	assert.ErrorContains(suite.T(), err, "failed to parse statement")
+}
+
+func (suite *StatementsCmdTestSuite) TestQueryPages() {
+	for i := 0; i < 5; i++ {
+		_, err := suite.run(fmt.Sprintf(cmdStatement, i), "statements", "post")
+		assert.Nil(suite.T(), err)
+	}
+
+	statements := suite.query("--ascending")
+
+	assert.Len(suite.T(), statements, 5)
+
+	for i, stmt := range statements {
+		assert.Equal(suite.T(), fmt.Sprintf("http://example.com/activities/%d", i), stmt.Object.(*statement.Activity).ID)
+	}
+
+	assert.Len(suite.T(), suite.query("--max", "3"), 3)
+}
+
+func (suite *StatementsCmdTestSuite) TestVoid() {
+	out, err := suite.run(fmt.Sprintf(cmdStatement, 1), "statements", "post")
+	assert.Nil(suite.T(), err)
+
+	id := strings.TrimSpace(out)
+
+	_, err = suite.run("", "statements", "void", id)
+
+	assert.ErrorContains(suite.T(), err, "--actor")
+
+	out, err = suite.run("", "statements", "void", id, "--actor-mbox", "mailto:admin@example.com")
+
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), strings.Fields(out), 1)
+
+	// Only the voiding statement is left
+	statements := suite.query()
+
+	assert.Len(suite.T(), statements, 1)
+	assert.Equal(suite.T(), statement.VerbVoided, statements[0].Verb.ID)
+
+	out, err = suite.run("", "statements", "query", "--voided-statement-id", id)
+
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), out, id)
+}
+
+func (suite *StatementsCmdTestSuite) TestQueryFailure() {
+	var out bytes.Buffer
+
+	err := cmd.Run([]string{"statements", "query", "--endpoint", suite.server.URL + "/", "--version", lrs.Version,
+		"--username", "test", "--password", "wrong"}, nil, &out, io.Discard)
+
+	assert.ErrorContains(suite.T(), err, "lrs responded with 401")
+	assert.NotContains(suite.T(), out.String(), "[]")
+}
+
+func TestStatementsCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(StatementsCmdTestSuite))
+}
@@ -2,7 +2,6 @@ package tests
 
 import (
 	"testing"
-	"time"
 
 	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
 	"github.com/burakkaraceylan/xapi-go/pkg/utils"
@@ -53,7 +52,7 @@ func (suite *SubstatementTestSuite) TestWithOptionals() {
 		Result: &statement.Result{
 			Success: utils.Ptr(true),
 		},
-		Timestamp:   &time.Time{},
+		Timestamp:   &statement.Timestamp{},
 		Attachments: []statement.Attachment{*attachment},
 	}
 
@@ -63,7 +62,7 @@ func (suite *SubstatementTestSuite) TestWithOptionals() {
 	assert.Equal(suite.T(), suite.Agent, ss.Actor)
 	assert.Equal(suite.T(), suite.Verb, ss.Verb)
 	assert.Equal(suite.T(), suite.Activity, ss.Object)
-	assert.Equal(suite.T(), time.Time{}, *ss.Timestamp)
+	assert.Equal(suite.T(), statement.Timestamp{}, *ss.Timestamp)
 	assert.Equal(suite.T(), "test", *ss.Context.Registration)
 	assert.Equal(suite.T(), true, *ss.Result.Success)
 	assert.Equal(suite.T(), 1, len(ss.Attachments))
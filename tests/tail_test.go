@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/tail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TailTestSuite struct {
+	suite.Suite
+	server     *httptest.Server
+	mu         sync.Mutex
+	stored     []map[string]any
+	consistent string
+	queries    []string
+}
+
+func (suite *TailTestSuite) SetupTest() {
+	suite.stored = nil
+	suite.consistent = ""
+	suite.queries = nil
+
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+
+		q := r.URL.Query()
+		suite.queries = append(suite.queries, r.URL.RawQuery)
+
+		statements := []map[string]any{}
+
+		for _, stmt := range suite.stored {
+			if since := q.Get("since"); len(since) == 0 || stmt["stored"].(string) > since {
+				statements = append(statements, stmt)
+			}
+		}
+
+		sort.Slice(statements, func(i, j int) bool {
+			less := statements[i]["stored"].(string) < statements[j]["stored"].(string)
+
+			if q.Get("ascending") == "true" {
+				return less
+			}
+
+			return !less
+		})
+
+		if q.Get("limit") == "1" && len(statements) > 1 {
+			statements = statements[:1]
+		}
+
+		if len(suite.consistent) > 0 {
+			w.Header().Set("X-Experience-API-Consistent-Through", suite.consistent)
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"statements": statements, "more": ""})
+	}))
+}
+
+func (suite *TailTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *TailTestSuite) store(id int, stored string) {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+
+	stmt := map[string]any{}
+	json.Unmarshal([]byte(fmt.Sprintf(replicateStatement, fmt.Sprintf("00000000-0000-0000-0000-%012d", id),
+		"http://adlnet.gov/expapi/verbs/experienced", `{"id": "http://example.com/activity"}`, stored)), &stmt)
+	stmt["stored"] = stored
+
+	suite.stored = append(suite.stored, stmt)
+}
+
+func (suite *TailTestSuite) tailer(params ...*tail.Options) *tail.Tailer {
+	lrs, err := client.NewRemoteLRS(suite.server.URL+"/", "1.0.3", "Basic dGVzdDp0ZXN0")
+	assert.Nil(suite.T(), err)
+
+	return tail.NewTailer(lrs, params...)
+}
+
+// Polls once and returns the ids of the statements handled
+func poll(t *tail.Tailer) ([]string, error) {
+	var ids []string
+
+	err := t.Poll(func(stmt statement.Statement) error {
+		ids = append(ids, *stmt.ID)
+		return nil
+	})
+
+	return ids, err
+}
+
+func (suite *TailTestSuite) TestPoll() {
+	suite.store(1, "2022-01-01T00:00:01.000Z")
+
+	t := suite.tailer(&tail.Options{Query: client.StatementQueryParams{Activity: &statement.Activity{ID: "http://example.com/activity"}}})
+
+	// Tailing starts after the latest statement
+	ids, err := poll(t)
+
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), ids)
+	assert.Contains(suite.T(), suite.queries[0], "limit=1")
+	assert.Contains(suite.T(), suite.queries[1], "activity=")
+
+	suite.store(2, "2022-01-01T00:00:02.000Z")
+	suite.store(3, "2022-01-01T00:00:03.000Z")
+	suite.consistent = "2022-01-01T00:00:02.000Z"
+
+	ids, err = poll(t)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"00000000-0000-0000-0000-000000000002", "00000000-0000-0000-0000-000000000003"}, ids)
+
+	// Polls go back right before the consistent through time, statements showing up late are handled once
+	suite.store(4, "2022-01-01T00:00:02.500Z")
+	suite.store(5, "2022-01-01T00:00:02.000Z")
+
+	ids, err = poll(t)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"00000000-0000-0000-0000-000000000005", "00000000-0000-0000-0000-000000000004"}, ids)
+	assert.Contains(suite.T(), suite.queries[len(suite.queries)-1], "since=2022-01-01T00%3A00%3A01.999Z")
+
+	// Once consistent, polls start from the latest statement
+	suite.consistent = "2022-01-01T00:00:03.000Z"
+
+	for i := 0; i < 2; i++ {
+		ids, err = poll(t)
+
+		assert.Nil(suite.T(), err)
+		assert.Empty(suite.T(), ids)
+	}
+
+	assert.Contains(suite.T(), suite.queries[len(suite.queries)-1], "since=2022-01-01T00%3A00%3A03.000Z")
+}
+
+func (suite *TailTestSuite) TestSince() {
+	suite.store(1, "2022-01-01T00:00:01.000Z")
+	suite.store(2, "2022-01-01T00:00:02.000Z")
+
+	since := time.Date(2022, 1, 1, 0, 0, 1, 0, time.UTC)
+
+	ids, err := poll(suite.tailer(&tail.Options{Since: &since}))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"00000000-0000-0000-0000-000000000002"}, ids)
+}
+
+func (suite *TailTestSuite) TestRun() {
+	t := suite.tailer(&tail.Options{Interval: time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Nothing is stored yet, the statement is stored while polling
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		suite.store(1, time.Now().Add(time.Second).UTC().Format("2006-01-02T15:04:05.000Z"))
+	}()
+
+	var ids []string
+
+	err := t.Run(ctx, func(stmt statement.Statement) error {
+		ids = append(ids, *stmt.ID)
+		cancel()
+		return nil
+	})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"00000000-0000-0000-0000-000000000001"}, ids)
+	assert.Contains(suite.T(), suite.queries[0], "limit=1")
+}
+
+func TestTailTestSuite(t *testing.T) {
+	suite.Run(t, new(TailTestSuite))
+}
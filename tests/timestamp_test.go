@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/client"
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TimestampTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TimestampTestSuite) TestMarshal() {
+	ts := statement.NewTimestamp(time.Date(2022, 1, 1, 10, 0, 0, 123456789, time.UTC))
+
+	b, err := json.Marshal(ts)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), `"2022-01-01T10:00:00.123Z"`, string(b))
+
+	ts = statement.NewTimestamp(time.Date(2022, 1, 1, 10, 0, 0, 0, time.FixedZone("", 3*60*60)))
+
+	assert.Equal(suite.T(), "2022-01-01T10:00:00.000+03:00", ts.String())
+}
+
+func (suite *TimestampTestSuite) TestParse() {
+	expected := time.Date(2022, 1, 1, 7, 0, 0, 500000000, time.UTC)
+
+	for _, s := range []string{
+		"2022-01-01T07:00:00.5Z",
+		"2022-01-01T10:00:00.500+03:00",
+		"2022-01-01T10:00:00.5+0300",
+		"2022-01-01T10:00:00.5+03",
+	} {
+		ts, err := statement.ParseTimestamp(s)
+
+		assert.Nil(suite.T(), err, s)
+		assert.True(suite.T(), expected.Equal(ts.Time), s)
+	}
+
+	for _, s := range []string{"", "2022-01-01", "2022-01-01T10:00:00", "2022-01-01T10:00:00.123"} {
+		_, err := statement.ParseTimestamp(s)
+		assert.NotNil(suite.T(), err, s)
+	}
+}
+
+func (suite *TimestampTestSuite) TestRoundTrip() {
+	ts := statement.NewTimestamp(time.Now())
+
+	b, err := json.Marshal(ts)
+	assert.Nil(suite.T(), err)
+
+	parsed := statement.Timestamp{}
+
+	assert.Nil(suite.T(), json.Unmarshal(b, &parsed))
+	assert.True(suite.T(), ts.Equal(parsed.Time))
+
+	// Parsed timestamps are marshaled as they were written
+	for _, s := range []string{"2022-01-01T10:00:00.123456789+00:00", "2022-01-01T10:00:00Z", "2022-01-01T10:00:00.5+0300"} {
+		b, _ := json.Marshal(s)
+
+		assert.Nil(suite.T(), json.Unmarshal(b, &parsed), s)
+
+		encoded, err := json.Marshal(parsed)
+
+		assert.Nil(suite.T(), err, s)
+		assert.Equal(suite.T(), string(b), string(encoded))
+	}
+}
+
+func (suite *TimestampTestSuite) TestQueryEncoding() {
+	var since, until string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since = r.URL.Query().Get("since")
+		until = r.URL.Query().Get("until")
+		w.Write([]byte(`{"statements":[]}`))
+	}))
+	defer server.Close()
+
+	lrs, err := client.NewRemoteLRS(server.URL+"/", "1.0.3", "Basic dGVzdDp0ZXN0")
+	assert.Nil(suite.T(), err)
+
+	t := time.Date(2022, 1, 1, 13, 0, 0, 123456789, time.FixedZone("", 3*60*60))
+
+	// since can be floored to milliseconds, until keeps its precision so no statement stored before it is left out
+	_, _, err = lrs.QueryStatements(&client.StatementQueryParams{Since: &t, Until: &t})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "2022-01-01T10:00:00.123Z", since)
+	assert.Equal(suite.T(), "2022-01-01T10:00:00.123456789Z", until)
+
+	t = t.Truncate(time.Millisecond)
+
+	_, _, err = lrs.QueryStatements(&client.StatementQueryParams{Until: &t})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "2022-01-01T10:00:00.123Z", until)
+}
+
+func TestTimestampTestSuite(t *testing.T) {
+	suite.Run(t, new(TimestampTestSuite))
+}
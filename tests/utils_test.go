@@ -1,6 +1,9 @@
 package tests
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
@@ -84,6 +87,34 @@ func (suite *UtilsTestSuite) TestToJSON() {
 	assert.Greater(suite.T(), len(s2), len(s))
 }
 
+func (suite *UtilsTestSuite) TestWriteFileAtomic() {
+	dir := suite.T().TempDir()
+	path := filepath.Join(dir, "file.json")
+
+	assert.Nil(suite.T(), utils.WriteFileAtomic(path, []byte("first"), 0600))
+	assert.Nil(suite.T(), utils.WriteFileAtomic(path, []byte("second"), 0644))
+
+	b, err := os.ReadFile(path)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "second", string(b))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+
+		assert.Nil(suite.T(), err)
+		assert.Equal(suite.T(), os.FileMode(0644), info.Mode().Perm())
+	}
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(dir)
+
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), entries, 1)
+
+	assert.NotNil(suite.T(), utils.WriteFileAtomic(filepath.Join(dir, "missing", "file.json"), []byte("first"), 0600))
+}
+
 func TestUtilsTestSuite(t *testing.T) {
 	suite.Run(t, new(UtilsTestSuite))
 }
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/burakkaraceylan/xapi-go/pkg/resources/statement"
+	"github.com/burakkaraceylan/xapi-go/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ValidateTestSuite) TestCorpus() {
+	files, err := filepath.Glob(filepath.Join("testdata", "conformance", "*.json"))
+	assert.Nil(suite.T(), err)
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		assert.Nil(suite.T(), err, file)
+
+		stmt := statement.Statement{}
+		assert.Nil(suite.T(), json.Unmarshal(b, &stmt), file)
+		assert.Nil(suite.T(), stmt.Validate(), file)
+	}
+}
+
+func (suite *ValidateTestSuite) TestInvalid() {
+	stmt := statement.NewStatement(
+		&statement.Agent{Mbox: utils.Ptr("mailto:learner@example.com"), OpenID: utils.Ptr("http://openid.example.com/learner")},
+		statement.Verb{ID: "completed", Display: statement.LanguageMap{"en_US": "completed"}},
+		&statement.SubStatement{Statement: statement.Statement{
+			Actor:  &statement.Group{},
+			Verb:   statement.Verb{ID: "http://adlnet.gov/expapi/verbs/attempted"},
+			Object: statement.StatementRef{ID: "1"},
+		}},
+	)
+	stmt.ID = utils.Ptr("not-a-uuid")
+	stmt.Context = &statement.Context{Registration: utils.Ptr("registration")}
+	stmt.Result = &statement.Result{Score: &statement.Score{Scaled: utils.Ptr(2.0)}}
+
+	err := stmt.Validate()
+
+	var errs statement.ValidationErrors
+
+	assert.True(suite.T(), errors.As(err, &errs))
+
+	paths := make([]string, len(errs))
+
+	for i, e := range errs {
+		paths[i] = e.Path
+	}
+
+	assert.Equal(suite.T(), []string{
+		"id",
+		"actor",
+		"verb.id",
+		"verb.display",
+		"object.actor.members",
+		"object.object.id",
+		"result.score",
+		"context.registration",
+	}, paths)
+
+	assert.NotNil(suite.T(), statement.Statement{}.Validate())
+}
+
+func (suite *ValidateTestSuite) TestSpecRules() {
+	nested := statement.NewSubStatement(
+		&statement.Agent{Mbox: utils.Ptr("learner@example.com")},
+		statement.Verb{ID: "http://adlnet.gov/expapi/verbs/attempted"},
+		statement.NewSubStatement(
+			statement.NewAgentWithMbox("Learner", "learner@example.com"),
+			statement.Verb{ID: "http://adlnet.gov/expapi/verbs/attempted"},
+			statement.NewActivity("http://example.com/activity"),
+		),
+	)
+	nested.ID = utils.Ptr("fd41c918-b88b-4b20-a0a5-a4c32391aaa0")
+
+	stmt := statement.NewStatement(
+		&statement.Group{ObjectType: "Group", Members: []statement.Agent{
+			{MboxSHA1Sum: utils.Ptr("not-a-sha1")},
+			{Account: &statement.Account{HomePage: "example.com"}},
+		}},
+		statement.Verb{ID: "http://adlnet.gov/expapi/verbs/voided"},
+		nested,
+	)
+	stmt.Attachments = []statement.Attachment{{UsageType: "signature", Length: -1}}
+
+	err := stmt.Validate()
+
+	var errs statement.ValidationErrors
+
+	assert.True(suite.T(), errors.As(err, &errs))
+
+	paths := make([]string, len(errs))
+
+	for i, e := range errs {
+		paths[i] = e.Path
+	}
+
+	assert.Equal(suite.T(), []string{
+		"actor.members[0].mbox_sha1sum",
+		"actor.members[1].account.homePage",
+		"actor.members[1].account.name",
+		"object.actor.mbox",
+		"object",
+		"object.object",
+		"attachments[0].usageType",
+		"attachments[0].display",
+		"attachments[0].contentType",
+		"attachments[0].length",
+		"attachments[0].sha2",
+		"object",
+	}, paths)
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}